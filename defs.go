@@ -4,16 +4,24 @@
 
 package goncurses
 
-// #cgo !windows pkg-config: ncurses
+// #cgo !windows pkg-config: ncursesw
 // #include <curses.h>
+// #include "goncurses.h"
 import "C"
 
 // Synconize options for Sync() function
 const (
 	SYNC_NONE   = iota
 	SYNC_CURSOR // Sync cursor in all sub/derived windows
-	SYNC_DOWN   // Sync changes in all parent windows
-	SYNC_UP     // Sync change in all child windows
+	SYNC_DOWN   // Sync window with changes in all parent windows
+	SYNC_UP     // Sync changes in window to all parent windows
+)
+
+// Title alignment options for DrawBox() function
+const (
+	ALIGN_LEFT = iota
+	ALIGN_CENTER
+	ALIGN_RIGHT
 )
 
 type Char C.chtype
@@ -31,6 +39,7 @@ const (
 	A_INVIS           = C.A_INVIS
 	A_ALTCHARSET      = C.A_ALTCHARSET
 	A_CHARTEXT        = C.A_CHARTEXT
+	A_COLOR           = C.A_COLOR
 )
 
 var attrList = map[C.int]string{
@@ -47,7 +56,8 @@ var attrList = map[C.int]string{
 	C.A_CHARTEXT:   "chartext",
 }
 
-// Definitions for printed characters not found on most keyboards.
+// Definitions for printed characters not found on most keyboards. See ACS
+// for the value of these characters on the current terminal.
 const (
 	/* VT100 symbols */
 	ACS_ULCORNER Char = C.A_ALTCHARSET + 'l'
@@ -102,6 +112,19 @@ const (
 	C_YELLOW        = C.COLOR_YELLOW
 )
 
+// colorNames maps the lower case names of the basic colors, as accepted by
+// Window.SetColor, to their values
+var colorNames = map[string]int16{
+	"black":   C_BLACK,
+	"blue":    C_BLUE,
+	"cyan":    C_CYAN,
+	"green":   C_GREEN,
+	"magenta": C_MAGENTA,
+	"red":     C_RED,
+	"white":   C_WHITE,
+	"yellow":  C_YELLOW,
+}
+
 type Key int
 
 const (
@@ -125,6 +148,18 @@ const (
 	KEY_F10           = C.KEY_F0 + 10   // F10 key
 	KEY_F11           = C.KEY_F0 + 11   // F11 key
 	KEY_F12           = C.KEY_F0 + 12   // F12 key
+	KEY_F13           = C.KEY_F0 + 13   // F13 key
+	KEY_F14           = C.KEY_F0 + 14   // F14 key
+	KEY_F15           = C.KEY_F0 + 15   // F15 key
+	KEY_F16           = C.KEY_F0 + 16   // F16 key
+	KEY_F17           = C.KEY_F0 + 17   // F17 key
+	KEY_F18           = C.KEY_F0 + 18   // F18 key
+	KEY_F19           = C.KEY_F0 + 19   // F19 key
+	KEY_F20           = C.KEY_F0 + 20   // F20 key
+	KEY_F21           = C.KEY_F0 + 21   // F21 key
+	KEY_F22           = C.KEY_F0 + 22   // F22 key
+	KEY_F23           = C.KEY_F0 + 23   // F23 key
+	KEY_F24           = C.KEY_F0 + 24   // F24 key
 	KEY_DL            = C.KEY_DL        // delete-line key
 	KEY_IL            = C.KEY_IL        // insert-line key
 	KEY_DC            = C.KEY_DC        // delete-character key
@@ -207,6 +242,7 @@ const (
 	KEY_MOUSE         = C.KEY_MOUSE     // any mouse event
 	KEY_RESIZE        = C.KEY_RESIZE    // Terminal resize event
 	//KEY_EVENT         = C.KEY_EVENT     // We were interrupted by an event
+	KEY_MIN = C.KEY_MIN // Minimum key value is KEY_BREAK (0401)
 	KEY_MAX = C.KEY_MAX // Maximum key value is KEY_EVENT (0633)
 )
 
@@ -232,14 +268,53 @@ var keyList = map[Key]string{
 	KEY_F10:       "F10",
 	KEY_F11:       "F11",
 	KEY_F12:       "F12",
+	KEY_F13:       "F13",
+	KEY_F14:       "F14",
+	KEY_F15:       "F15",
+	KEY_F16:       "F16",
+	KEY_F17:       "F17",
+	KEY_F18:       "F18",
+	KEY_F19:       "F19",
+	KEY_F20:       "F20",
+	KEY_F21:       "F21",
+	KEY_F22:       "F22",
+	KEY_F23:       "F23",
+	KEY_F24:       "F24",
 	KEY_MOUSE:     "mouse",
 	KEY_PAGEUP:    "page up",
 	KEY_PAGEDOWN:  "page down",
+	KEY_END:       "end",
+	KEY_DC:        "delete",
+	KEY_IC:        "insert",
+	KEY_DL:        "delete line",
+	KEY_IL:        "insert line",
+	KEY_CLEAR:     "clear",
+	KEY_EOS:       "clear to end of screen",
+	KEY_EOL:       "clear to end of line",
+	KEY_SF:        "scroll forward",
+	KEY_SR:        "scroll backward",
+	KEY_BTAB:      "shift tab",
+	KEY_BEG:       "begin",
+	KEY_PRINT:     "print",
+	KEY_RESIZE:    "resize",
+	KEY_A1:        "keypad upper left",
+	KEY_A3:        "keypad upper right",
+	KEY_B2:        "keypad center",
+	KEY_C1:        "keypad lower left",
+	KEY_C3:        "keypad lower right",
+	KEY_SLEFT:     "shift left",
+	KEY_SRIGHT:    "shift right",
+	KEY_SHOME:     "shift home",
+	KEY_SEND:      "shift end",
+	KEY_SDC:       "shift delete",
+	KEY_SIC:       "shift insert",
 }
 
 type MouseButton int
 
-// Mouse button events
+// Mouse button events. Button 4 and button 5 events are usually generated
+// by scrolling the mouse wheel up and down respectively. The button 5 events
+// will be zero (0) if the version of ncurses being used does not support them.
 const (
 	M_ALL            MouseButton = C.ALL_MOUSE_EVENTS
 	M_ALT                        = C.BUTTON_ALT      // alt-click
@@ -263,6 +338,11 @@ const (
 	M_B4_CLICKED                 = C.BUTTON4_CLICKED
 	M_B4_DBL_CLICKED             = C.BUTTON4_DOUBLE_CLICKED
 	M_B4_TPL_CLICKED             = C.BUTTON4_TRIPLE_CLICKED
+	M_B5_PRESSED                 = C.BUTTON5_PRESSED // button 5 (scroll wheel)
+	M_B5_RELEASED                = C.BUTTON5_RELEASED
+	M_B5_CLICKED                 = C.BUTTON5_CLICKED
+	M_B5_DBL_CLICKED             = C.BUTTON5_DOUBLE_CLICKED
+	M_B5_TPL_CLICKED             = C.BUTTON5_TRIPLE_CLICKED
 	M_CTRL                       = C.BUTTON_CTRL           // ctrl-click
 	M_SHIFT                      = C.BUTTON_SHIFT          // shift-click
 	M_POSITION                   = C.REPORT_MOUSE_POSITION // mouse moved
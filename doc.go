@@ -32,6 +32,20 @@
 // concurrently. Failure to do so will result in unpredictable and
 // undefined behaviour in your program.
 //
+// goncurses deliberately does not lock internally. A blocking call such as
+// GetChar would hold any such lock while waiting for the user, preventing
+// other goroutines from updating the screen in the meantime, and a lock per
+// call would not make a sequence of calls, such as a Move followed by a
+// Print, atomic anyway. Only the application knows which calls belong
+// together, so the locking is left to it.
+//
+// Every window is already an offscreen buffer. Drawing to a window only
+// changes its contents in memory and nothing is sent to the terminal until
+// it is refreshed, at which point ncurses compares the window against what
+// is already on screen and outputs only the cells which differ. To draw a
+// frame without flicker, Erase (not Clear) and redraw each window, call
+// NoutRefresh on each of them and then call Update once.
+//
 // The examples directory contains demontrations of many of the capabilities
 // goncurses can provide.
 package goncurses
@@ -20,3 +20,17 @@ func ExampleInit() {
 	stdscr.Print("Press enter to continue...")
 	stdscr.Refresh()
 }
+
+func ExampleRun() {
+	// Run takes care of calling Init and End, even if the function panics.
+	err := goncurses.Run(func(stdscr *goncurses.Window) error {
+		stdscr.Print("Press any key to continue...")
+		stdscr.Refresh()
+		stdscr.GetChar()
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
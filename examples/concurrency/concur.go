@@ -49,7 +49,7 @@ func main() {
 		var c gc.Char
 		select {
 		case c = <-in: // blocks while waiting for input from goroutine
-			scr.Print(string(c))
+			scr.Print(string(rune(c)))
 			scr.Refresh()
 		case ready <- true: // sends once above block completes
 		}
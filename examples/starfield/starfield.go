@@ -364,7 +364,10 @@ func main() {
 	objects = append(objects, ship)
 
 	field := genStarfield(pl, pc)
-	text := stdscr.Duplicate()
+	text, err := stdscr.Duplicate()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	c := time.NewTicker(time.Second / 2)
 	c2 := time.NewTicker(time.Second / 16)
@@ -0,0 +1,9 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses
+
+// RunWith exposes run to the tests in package goncurses_test
+var RunWith = run
@@ -6,7 +6,7 @@
 
 package goncurses
 
-// #cgo pkg-config: form
+// #cgo pkg-config: formw
 // #include <form.h>
 // #include <stdlib.h>
 import "C"
@@ -163,8 +163,10 @@ func NewForm(fields []*Field) (Form, error) {
 		fields = append(fields, nil)
 	}
 	form, err := C.new_form((**C.FIELD)(unsafe.Pointer(&fields[0])))
-
-	return Form{form}, ncursesError(err)
+	if form == nil {
+		return Form{}, ncursesError(err)
+	}
+	return Form{form}, nil
 }
 
 // FieldCount returns the number of fields attached to the Form
@@ -227,7 +229,7 @@ func (f *Form) SetWindow(w *Window) error {
 
 // Sub returns the subwindow assocaiated with the form
 func (f *Form) Sub() Window {
-	return Window{C.form_sub(f.form)}
+	return Window{win: C.form_sub(f.form)}
 }
 
 // UnPost the form, removing it from the interface
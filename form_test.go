@@ -0,0 +1,62 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows
+
+package goncurses_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestForm(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	name, err := goncurses.NewField(1, 10, 1, 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer name.Free()
+	notes, err := goncurses.NewField(2, 5, 3, 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer notes.Free()
+
+	form, err := goncurses.NewForm([]*goncurses.Field{name, notes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer form.Free()
+	if n := form.FieldCount(); n != 2 {
+		t.Errorf("expected 2 fields, got %d", n)
+	}
+	if err := form.Post(); err != nil {
+		t.Fatal(err)
+	}
+	defer form.UnPost()
+
+	drive := func(reqs ...goncurses.Key) {
+		for _, req := range reqs {
+			if err := form.Driver(req); err != nil {
+				t.Fatalf("request %d: %v", req, err)
+			}
+		}
+	}
+	drive('b', 'o', 'x', goncurses.REQ_PREV_CHAR, goncurses.REQ_DEL_CHAR)
+	drive(goncurses.REQ_NEXT_FIELD, 'a', 'b', 'c', 'd', 'e', 'f', 'g')
+	drive(goncurses.REQ_VALIDATION)
+
+	if s := strings.TrimRight(name.Buffer(), " "); s != "bo" {
+		t.Errorf("expected \"bo\" in single-line field, got %q", s)
+	}
+	if s := notes.Buffer(); s != "abcdefg   " {
+		t.Errorf("expected text to wrap in multi-line field, got %q", s)
+	}
+}
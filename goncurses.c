@@ -26,11 +26,26 @@ int ncurses_wattroff(WINDOW *win, int attr) { return wattroff(win, attr); }
 int ncurses_wattron(WINDOW *win, int attr) { return wattron(win, attr); }
 #endif
 
+/* alloc_pair and free_pair were added in ncurses 6.1 */
+#if defined(PDCURSES) || NCURSES_VERSION_MAJOR < 6 || \
+	(NCURSES_VERSION_MAJOR == 6 && NCURSES_VERSION_MINOR < 1)
+bool ncurses_has_alloc_pair(void) { return false; }
+int ncurses_alloc_pair(int fg, int bg) { return ERR; }
+int ncurses_free_pair(int pair) { return ERR; }
+#else
+bool ncurses_has_alloc_pair(void) { return true; }
+int ncurses_alloc_pair(int fg, int bg) { return alloc_pair(fg, bg); }
+int ncurses_free_pair(int pair) { return free_pair(pair); }
+#endif
+
+chtype ncurses_acs(int c) { return acs_map[(unsigned char) c]; }
 int ncurses_COLOR_PAIR(int p) { return COLOR_PAIR(p); }
+int ncurses_PAIR_NUMBER(int attr) { return PAIR_NUMBER(attr); }
 chtype ncurses_getbkgd(WINDOW *win) { return getbkgd(win); }
 void ncurses_getyx(WINDOW *win, int *y, int *x) { getyx(win, *y, *x); }
 void ncurses_getbegyx(WINDOW *win, int *y, int *x) { getbegyx(win, *y, *x); }
 void ncurses_getmaxyx(WINDOW *win, int *y, int *x) { getmaxyx(win, *y, *x); }
+void ncurses_getparyx(WINDOW *win, int *y, int *x) { getparyx(win, *y, *x); }
 
 WINDOW *ncurses_wgetparent(const WINDOW *win) {
 #ifdef PDCURSES
@@ -40,6 +55,16 @@ WINDOW *ncurses_wgetparent(const WINDOW *win) {
 #endif
 }
 
+int ncurses_wgetdelay(const WINDOW *win) {
+#ifdef PDCURSES
+	if (win->_nodelay)
+		return 0;
+	return win->_delayms > 0 ? win->_delayms : -1;
+#else
+	return wgetdelay(win);
+#endif
+}
+
 bool ncurses_is_cleared(const WINDOW *win) {
 #ifdef PDCURSES
 	return win->_clear;
@@ -64,6 +89,14 @@ bool ncurses_is_pad(const WINDOW *win) {
 #endif
 }
 
+bool ncurses_is_scrollok(const WINDOW *win) {
+#ifdef PDCURSES
+	return win->_scroll;
+#else
+	return is_scrollok(win);
+#endif
+}
+
 bool ncurses_is_subwin(const WINDOW *win) {
 #ifdef PDCURSES
 	return win->_parent != NULL;
@@ -81,8 +114,50 @@ bool ncurses_has_mouse(void) {
 #endif
 }
 
+int ncurses_escdelay(void) {
+#ifdef PDCURSES
+	return 0; /* escape sequences are not used to report keys */
+#else
+	return ESCDELAY;
+#endif
+}
+
+int ncurses_set_escdelay(int ms) {
+#ifdef PDCURSES
+	return OK;
+#else
+	return set_escdelay(ms);
+#endif
+}
+
+int ncurses_set_tabsize(int size) {
+#ifdef PDCURSES
+	TABSIZE = size;
+	return OK;
+#else
+	return set_tabsize(size);
+#endif
+}
+
+int ncurses_tabsize(void) { return TABSIZE; }
+int ncurses_wgetscrreg(const WINDOW *win, int *top, int *bottom) {
+#ifdef PDCURSES
+	*top = win->_tmarg;
+	*bottom = win->_bmarg;
+	return OK;
+#else
+	return wgetscrreg(win, top, bottom);
+#endif
+}
+
 int ncurses_touchwin(WINDOW *win) { return touchwin(win); }
 int ncurses_untouchwin(WINDOW *win) { return untouchwin(win); }
+int ncurses_wattr_get(WINDOW *win, attr_t *attr, short *pair) {
+	return wattr_get(win, attr, pair, NULL);
+}
+int ncurses_wattr_set(WINDOW *win, attr_t attr, short pair) {
+	return wattr_set(win, attr, pair, NULL);
+}
 int ncurses_wattrset(WINDOW *win, int attr) { return wattrset(win, attr); }
 int ncurses_wstandend(WINDOW *win) { return wstandend(win); }
 int ncurses_wstandout(WINDOW *win) { return wstandout(win); }
@@ -9,22 +9,46 @@ bool is_term_resized(int y, int x);
 int resizeterm(int y, int x);
 #endif
 
+/* Older versions of ncurses do not report button 5 (scroll wheel) events */
+#ifndef BUTTON5_PRESSED
+#define BUTTON5_RELEASED 0
+#define BUTTON5_PRESSED 0
+#define BUTTON5_CLICKED 0
+#define BUTTON5_DOUBLE_CLICKED 0
+#define BUTTON5_TRIPLE_CLICKED 0
+#endif
+
+chtype ncurses_acs(int c);
+int ncurses_alloc_pair(int fg, int bg);
 int ncurses_COLOR_PAIR(int p);
+int ncurses_PAIR_NUMBER(int attr);
+int ncurses_escdelay(void);
 chtype ncurses_getbkgd(WINDOW *win);
+int ncurses_free_pair(int pair);
 void ncurses_getbegyx(WINDOW *win, int *y, int *x);
 void ncurses_getmaxyx(WINDOW *win, int *y, int *x);
+void ncurses_getparyx(WINDOW *win, int *y, int *x);
 int ncurses_getmouse(MEVENT *me);
 void ncurses_getyx(WINDOW *win, int *y, int *x);
+bool ncurses_has_alloc_pair(void);
 int ncurses_has_key(int);
 bool ncurses_has_mouse(void);
 bool ncurses_is_cleared(const WINDOW *win);
 bool ncurses_is_keypad(const WINDOW *win);
 bool ncurses_is_pad(const WINDOW *win);
+bool ncurses_is_scrollok(const WINDOW *win);
 bool ncurses_is_subwin(const WINDOW *win);
+int ncurses_set_escdelay(int ms);
+int ncurses_set_tabsize(int size);
+int ncurses_tabsize(void);
 int ncurses_touchwin(WINDOW *win);
 int ncurses_ungetch(int ch);
 int ncurses_untouchwin(WINDOW *win);
 int ncurses_wattroff(WINDOW *, int);
+int ncurses_wgetdelay(const WINDOW *win);
+int ncurses_wgetscrreg(const WINDOW *win, int *top, int *bottom);
+int ncurses_wattr_get(WINDOW *win, attr_t *attr, short *pair);
+int ncurses_wattr_set(WINDOW *win, attr_t attr, short pair);
 int ncurses_wattron(WINDOW *, int);
 int ncurses_wattrset(WINDOW *win, int attr);
 WINDOW * ncurses_wgetparent(const WINDOW *win);
@@ -7,7 +7,7 @@
 package goncurses
 
 /*
-#cgo pkg-config: menu
+#cgo pkg-config: menuw
 #include <menu.h>
 #include <stdlib.h>
 
@@ -248,7 +248,7 @@ func (m *Menu) UnPost() error {
 
 // Window container for the menu. Returns nil on failure
 func (m *Menu) Window() *Window {
-	return &Window{C.menu_win(m.menu)}
+	return &Window{win: C.menu_win(m.menu)}
 }
 
 // NewItem creates a new menu item with name and description.
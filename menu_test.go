@@ -0,0 +1,71 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestMenu(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	names := []string{"apple", "banana", "cherry", "date"}
+	items := make([]*goncurses.MenuItem, len(names))
+	for i, name := range names {
+		item, err := goncurses.NewItem(name, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer item.Free()
+		items[i] = item
+	}
+	menu, err := goncurses.NewMenu(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer menu.Free()
+
+	win, err := goncurses.NewWindow(6, 20, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	if err := menu.SetWindow(win); err != nil {
+		t.Fatal(err)
+	}
+	sub := win.Derived(4, 18, 1, 1)
+	defer sub.Delete()
+	if err := menu.SubWindow(sub); err != nil {
+		t.Fatal(err)
+	}
+	if err := menu.Post(); err != nil {
+		t.Fatal(err)
+	}
+	defer menu.UnPost()
+
+	for i := 0; i < 2; i++ {
+		if err := menu.Driver(int(goncurses.REQ_DOWN)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if name := menu.Current(nil).Name(); name != "cherry" {
+		t.Errorf("expected current item to be cherry, got %s", name)
+	}
+	if err := menu.Driver(int(goncurses.REQ_LAST)); err != nil {
+		t.Fatal(err)
+	}
+	if err := menu.Driver(int(goncurses.REQ_DOWN)); err == nil {
+		t.Error("expected error moving past the last item")
+	}
+	if idx := menu.Current(nil).Index(); idx != len(names)-1 {
+		t.Errorf("expected current index %d, got %d", len(names)-1, idx)
+	}
+}
@@ -5,7 +5,7 @@
 
 package goncurses
 
-// #cgo !windows pkg-config: ncurses
+// #cgo !windows pkg-config: ncursesw
 // #cgo windows CFLAGS: -DNCURSES_MOUSE_VERSION
 // #cgo windows LDFLAGS: -lpdcurses
 // #include <curses.h>
@@ -22,6 +22,12 @@ type MouseEvent struct {
 	State   MouseButton /* button state */
 }
 
+// Has returns true if any of the OR'd mouse events in mask were reported
+// by the event
+func (e MouseEvent) Has(mask MouseButton) bool {
+	return e.State&mask != 0
+}
+
 // GetMouse returns the MouseEvent associated with a KEY_MOUSE event returned
 // by a call to GetChar(). Returns a new MouseEvent or nil on error or if no
 // event is currently in the mouse event queue
@@ -39,6 +45,23 @@ func GetMouse() *MouseEvent {
 	}
 }
 
+// UngetMouse pushes the given MouseEvent onto the mouse event queue and
+// places a KEY_MOUSE event in the input queue so that the event will be
+// returned by the next call to GetChar() and GetMouse()
+func UngetMouse(event *MouseEvent) error {
+	me := C.MEVENT{
+		id:     C.short(event.Id),
+		x:      C.int(event.X),
+		y:      C.int(event.Y),
+		z:      C.int(event.Z),
+		bstate: C.mmask_t(event.State),
+	}
+	if C.ungetmouse(&me) != C.OK {
+		return cursesError("ungetmouse")
+	}
+	return nil
+}
+
 // MouseOk returns true if ncurses has built-in mouse support. On ncurses 5.7
 // and earlier, this function is not present and so will always return false
 func MouseOk() bool {
@@ -58,6 +81,9 @@ func MouseInterval(ms int) int {
 // is triggered, GetChar() will return KEY_MOUSE. To retrieve the actual
 // event use GetMouse() to pop it off the queue. Pass a pointer as the
 // second argument to store the prior events being monitored or nil.
+// MouseMask returns the events which were actually enabled, which may be
+// fewer than those requested if the terminal does not support them. A
+// return value of zero (0) indicates the mouse could not be enabled.
 func MouseMask(mask MouseButton, old *MouseButton) MouseButton {
 	return MouseButton(C.mousemask((C.mmask_t)(mask),
 		(*C.mmask_t)(unsafe.Pointer(old))))
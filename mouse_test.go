@@ -0,0 +1,100 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestUngetMouse(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Keypad(true)
+	goncurses.MouseMask(goncurses.M_ALL, nil)
+	event := &goncurses.MouseEvent{Y: 3, X: 7,
+		State: goncurses.M_B1_CLICKED}
+	if err := goncurses.UngetMouse(event); err != nil {
+		t.Fatal(err)
+	}
+	if k := stdscr.GetChar(); k != goncurses.KEY_MOUSE {
+		t.Fatalf("expected KEY_MOUSE, got %d", k)
+	}
+	got := goncurses.GetMouse()
+	if got == nil {
+		t.Fatal("expected a mouse event")
+	}
+	if got.Y != 3 || got.X != 7 || got.State != goncurses.M_B1_CLICKED {
+		t.Errorf("expected %+v, got %+v", *event, *got)
+	}
+}
+
+func TestMouseEventHas(t *testing.T) {
+	event := goncurses.MouseEvent{
+		State: goncurses.M_B1_CLICKED | goncurses.M_CTRL}
+	if !event.Has(goncurses.M_B1_CLICKED) {
+		t.Error("expected event to have button 1 clicked")
+	}
+	if !event.Has(goncurses.M_CTRL) {
+		t.Error("expected event to have ctrl")
+	}
+	if event.Has(goncurses.M_B2_CLICKED) {
+		t.Error("expected event to not have button 2 clicked")
+	}
+}
+
+func TestEncloseAndMouseTrafo(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(10, 20, 2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	sub := win.Derived(3, 5, 1, 1)
+	defer sub.Delete()
+
+	if !sub.Enclose(3, 5) || !sub.Enclose(5, 9) {
+		t.Error("expected coordinates to be inside sub-window")
+	}
+	if sub.Enclose(2, 4) || sub.Enclose(6, 5) {
+		t.Error("expected coordinates to be outside sub-window")
+	}
+
+	y, x, ok := sub.MouseTrafo(4, 6, false)
+	if !ok || y != 1 || x != 1 {
+		t.Errorf("expected 1, 1, got %d, %d (%v)", y, x, ok)
+	}
+	y, x, ok = sub.MouseTrafo(1, 1, true)
+	if !ok || y != 4 || x != 6 {
+		t.Errorf("expected 4, 6, got %d, %d (%v)", y, x, ok)
+	}
+	if _, _, ok = sub.MouseTrafo(0, 0, false); ok {
+		t.Error("expected coordinates to be outside sub-window")
+	}
+}
+
+func TestMouseMask(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	var old goncurses.MouseButton
+	var mask goncurses.MouseButton = goncurses.M_B1_PRESSED |
+		goncurses.M_B1_RELEASED
+	if enabled := goncurses.MouseMask(mask, &old); enabled != mask {
+		t.Errorf("expected %#x to be enabled, got %#x", mask, enabled)
+	}
+	if old != 0 {
+		t.Errorf("expected no prior events, got %#x", old)
+	}
+	goncurses.MouseMask(0, &old)
+	if old != mask {
+		t.Errorf("expected prior events %#x, got %#x", mask, old)
+	}
+}
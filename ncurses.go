@@ -5,9 +5,11 @@
 
 package goncurses
 
-// #cgo !windows pkg-config: ncurses
+// #cgo !windows pkg-config: ncursesw
 // #cgo windows CFLAGS: -DNCURSES_MOUSE_VERSION
 // #cgo windows LDFLAGS: -lpdcurses
+// #include <locale.h>
+// #include <stdlib.h>
 // #include <curses.h>
 // #include "goncurses.h"
 import "C"
@@ -15,9 +17,153 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"math"
 	"unsafe"
 )
 
+var (
+	// ErrInputTimeout is returned by Window.ReadChar when no input was
+	// received before the delay set by HalfDelay or Window.Timeout expired
+	ErrInputTimeout = errors.New("Timed out waiting for input")
+
+	// ErrBadFGColor and ErrBadBGColor are returned by InitPair and
+	// Window.SetColor when the foreground or background color respectively
+	// is out of range or unknown
+	ErrBadFGColor = errors.New("Invalid foreground color")
+	ErrBadBGColor = errors.New("Invalid background color")
+)
+
+// CursesError is returned when a call to the underlying curses library
+// fails. Func holds the name of the curses function which failed.
+type CursesError struct {
+	Func string
+}
+
+func (e *CursesError) Error() string {
+	return "goncurses: " + e.Func + " failed"
+}
+
+// cursesError returns a CursesError for the named curses function
+func cursesError(fn string) error {
+	return &CursesError{fn}
+}
+
+// halfDelay records the delay, in tenths of a second, of half-delay mode or
+// zero if the terminal is not in half-delay mode, which can not be queried
+// from ncurses directly
+var halfDelay int
+
+// echo records whether typed characters are currently echoed, which can not
+// be queried from ncurses directly either. Echo is on for a new screen.
+var echo = true
+
+// ACS returns the value of the given ACS_* line drawing character for the
+// current terminal. The ACS_* constants assume the terminal supports the
+// VT100 alternate character set whereas ACS looks up the terminal's actual
+// mapping, which will be an ASCII approximation, such as '+' or '-', when
+// the terminal has no suitable character. The mapping is only available
+// after Init or NewTerm has been called.
+func ACS(ch Char) Char {
+	return Char(C.ncurses_acs(C.int(ch & A_CHARTEXT)))
+}
+
+// pairs maps the foreground and background colors of each pair allocated by
+// AllocPair or AllocPairN to its pair number
+var pairs = make(map[[2]int16]int)
+
+// AllocPair returns a color pair with the given foreground and background
+// colors, allocating and initializing the lowest unused pair number if the
+// combination has not been allocated before. This allows independent parts
+// of a program to share colors without having to coordinate pair numbers.
+// Pairs are allocated upward from 1, so these should not be assigned by
+// InitPair as well. An error is returned once all pairs have been used.
+func AllocPair(fg, bg int16) (int16, error) {
+	if pair, ok := pairs[[2]int16{fg, bg}]; ok {
+		return int16(pair), nil
+	}
+	used := make(map[int]bool, len(pairs))
+	for _, pair := range pairs {
+		used[pair] = true
+	}
+	pair := 1
+	for used[pair] {
+		pair++
+	}
+	if C.int(pair) >= C.COLOR_PAIRS || pair > math.MaxInt16 {
+		return 0, errors.New("No color pairs left to allocate")
+	}
+	if err := InitPair(int16(pair), fg, bg); err != nil {
+		return 0, err
+	}
+	pairs[[2]int16{fg, bg}] = pair
+	return int16(pair), nil
+}
+
+// AllocPairN behaves like AllocPair but uses the ncurses alloc_pair
+// function, which can allocate from the thousands of pairs supported by
+// 256 color terminals and reuses the least recently allocated pair once
+// they run out. Pairs should be released with FreePair when no longer
+// needed. With versions of ncurses prior to 6.1, which lack alloc_pair,
+// AllocPair is used instead. AllocPair and AllocPairN may be used together
+// and will not hand out the same pair for different colors.
+func AllocPairN(fg, bg int16) (int, error) {
+	if !C.ncurses_has_alloc_pair() {
+		pair, err := AllocPair(fg, bg)
+		return int(pair), err
+	}
+	if pair, ok := pairs[[2]int16{fg, bg}]; ok {
+		return pair, nil
+	}
+	pair := int(C.ncurses_alloc_pair(C.int(fg), C.int(bg)))
+	if pair == C.ERR {
+		return 0, cursesError("alloc_pair")
+	}
+	// alloc_pair may have reused a pair which held other colors
+	for colors, p := range pairs {
+		if p == pair {
+			delete(pairs, colors)
+		}
+	}
+	pairs[[2]int16{fg, bg}] = pair
+	return pair, nil
+}
+
+// FreePair releases a color pair allocated by AllocPair or AllocPairN so
+// that it can be reused.
+func FreePair(pair int) error {
+	for colors, p := range pairs {
+		if p != pair {
+			continue
+		}
+		if C.ncurses_has_alloc_pair() && C.ncurses_free_pair(C.int(pair)) ==
+			C.ERR {
+			return cursesError("free_pair")
+		}
+		delete(pairs, colors)
+		return nil
+	}
+	return errors.New("Color pair not allocated")
+}
+
+// batch counts the calls to Batch in progress. While it is non-zero Refresh
+// defers output to the enclosing Batch
+var batch int
+
+// Batch calls fn and then updates the screen once. Any calls to
+// Window.Refresh made by fn behave as Window.NoutRefresh, so that all of the
+// changes are sent to the terminal together, avoiding flicker. Calls to
+// Batch may be nested, in which case only the outermost call updates the
+// screen.
+func Batch(fn func()) error {
+	batch++
+	defer func() { batch-- }()
+	fn()
+	if batch > 1 {
+		return nil
+	}
+	return Update()
+}
+
 // BaudRate returns the speed of the terminal in bits per second
 func BaudRate() int {
 	return int(C.baudrate())
@@ -33,6 +179,7 @@ func Beep() {
 // Turn on/off buffering; raw user signals are passed to the program for
 // handling. Overrides raw mode
 func CBreak(on bool) {
+	halfDelay = 0
 	if on {
 		C.cbreak()
 		return
@@ -40,11 +187,6 @@ func CBreak(on bool) {
 	C.nocbreak()
 }
 
-func TypeAhead(fd int) int {
-
-	return int(C.typeahead(C.int(fd)))
-}
-
 // Test whether colour values can be changed
 func CanChangeColor() bool {
 	return bool(C.bool(C.can_change_color()))
@@ -64,22 +206,61 @@ func ColorPair(pair int16) Char {
 	return Char(C.ncurses_COLOR_PAIR(C.int(pair)))
 }
 
-// CursesVersion returns the version of the ncurses library currently linked to
+// CursesVersion returns the version of the ncurses library currently linked
+// to, such as "ncurses 6.2.20200212". It may be called before Init and is
+// useful in bug reports or to check for features added in later versions.
 func CursesVersion() string {
 	return C.GoString(C.curses_version())
 }
 
 // Set the cursor visibility. Options are: 0 (invisible/hidden), 1 (normal)
-// and 2 (extra-visible)
-func Cursor(vis byte) error {
-	if C.curs_set(C.int(vis)) == C.ERR {
-		return errors.New("Failed to enable ")
+// and 2 (extra-visible). The previous visibility is returned so that it can
+// be restored later. An error is returned if the terminal does not support
+// the requested visibility.
+func Cursor(vis byte) (prev int, err error) {
+	prev = int(C.curs_set(C.int(vis)))
+	if prev == C.ERR {
+		return 0, cursesError("curs_set")
+	}
+	return prev, nil
+}
+
+// DefProgMode saves the current terminal modes as the "program" (in curses)
+// state so they can be restored by ResetProgMode. This is normally used
+// before temporarily leaving curses mode, such as when running another
+// program which uses the terminal. The usual sequence is:
+//
+//	goncurses.DefProgMode()
+//	goncurses.End()
+//	// run the other program
+//	goncurses.ResetProgMode()
+//	stdscr.Refresh()
+func DefProgMode() error {
+	if C.def_prog_mode() == C.ERR {
+		return cursesError("def_prog_mode")
+	}
+	return nil
+}
+
+// DefShellMode saves the current terminal modes as the "shell" (not in
+// curses) state so they can be restored by ResetShellMode. This is done
+// automatically by Init and NewTerm.
+func DefShellMode() error {
+	if C.def_shell_mode() == C.ERR {
+		return cursesError("def_shell_mode")
 	}
 	return nil
 }
 
+// EscDelay returns the time, in milliseconds, curses waits after receiving
+// an escape for the rest of an escape sequence. See SetEscDelay.
+func EscDelay() int {
+	return int(C.ncurses_escdelay())
+}
+
 // Echo turns on/off the printing of typed characters
 func Echo(on bool) {
+	echo = on
 	if on {
 		C.echo()
 		return
@@ -103,21 +284,26 @@ func Flash() {
 // FlushInput flushes all input
 func FlushInput() error {
 	if C.flushinp() == C.ERR {
-		return errors.New("Flush input failed")
+		return cursesError("flushinp")
 	}
 	return nil
 }
 
 // Behaves like cbreak() but also adds a timeout for input. If timeout is
-// exceeded after a call to Getch() has been made then GetChar will return
-// with an error.
+// exceeded after a call to GetChar() has been made then GetChar will return
+// zero (0) and ReadChar will return ErrInputTimeout. Calling CBreak or Raw
+// ends half-delay mode.
 func HalfDelay(delay int) error {
 	var cerr C.int
 	if delay > 0 {
 		cerr = C.halfdelay(C.int(delay))
 	}
 	if cerr == C.ERR {
-		return errors.New("Unable to set delay mode")
+		return cursesError("halfdelay")
+	}
+	halfDelay = 0
+	if delay > 0 {
+		halfDelay = delay
 	}
 	return nil
 }
@@ -139,7 +325,11 @@ func HasInsertLine() bool {
 	return bool(C.has_il())
 }
 
-// HasKey returns true if terminal recognized the given character
+// HasKey returns true if the current terminal can generate the given key,
+// such as KEY_F13 or KEY_HOME, according to its terminfo description. It
+// can be used to avoid offering key bindings which the user has no way to
+// type. Keypad must have been enabled on a window beforehand, otherwise
+// HasKey reports false for every key.
 func HasKey(ch Key) bool {
 	if C.ncurses_has_key(C.int(ch)) == 1 {
 		return true
@@ -152,32 +342,53 @@ func HasKey(ch Key) bool {
 func InitColor(col, r, g, b int16) error {
 	if C.init_color(C.short(col), C.short(r), C.short(g),
 		C.short(b)) == C.ERR {
-		return errors.New("Failed to set new color definition")
+		return cursesError("init_color")
 	}
 	return nil
 }
 
-// InitPair sets a colour pair designated by 'pair' to fg and bg colors
+// InitPair sets a colour pair designated by 'pair' to fg and bg colors.
+// ErrBadFGColor or ErrBadBGColor is returned if either color is not one
+// supported by the terminal. A color of -1 may be used for the terminal's
+// default color after calling UseDefaultColors.
 func InitPair(pair, fg, bg int16) error {
 	if pair <= 0 || C.int(pair) > C.int(C.COLOR_PAIRS-1) {
 		return errors.New("Color pair out of range")
 	}
+	if fg < -1 || C.int(fg) >= C.COLORS {
+		return ErrBadFGColor
+	}
+	if bg < -1 || C.int(bg) >= C.COLORS {
+		return ErrBadBGColor
+	}
 	if C.init_pair(C.short(pair), C.short(fg), C.short(bg)) == C.ERR {
-		return errors.New("Failed to init color pair")
+		return cursesError("init_pair")
 	}
 	return nil
 }
 
 // Initialize the ncurses library. You must run this function prior to any
-// other goncurses function in order for the library to work
+// other goncurses function in order for the library to work. The locale is
+// set from the environment so that multi-byte (UTF-8) characters are
+// handled correctly.
 func Init() (stdscr *Window, err error) {
-	stdscr = &Window{C.initscr()}
+	setLocale()
+	echo = true
+	stdscr = &Window{win: C.initscr()}
 	if unsafe.Pointer(stdscr.win) == nil {
-		err = errors.New("An error occurred initializing ncurses")
+		err = cursesError("initscr")
 	}
 	return
 }
 
+// setLocale sets the program's locale from the environment, which ncurses
+// requires to support wide characters
+func setLocale() {
+	empty := C.CString("")
+	defer C.free(unsafe.Pointer(empty))
+	C.setlocale(C.LC_ALL, empty)
+}
+
 // IsEnd returns true if End() has been called, otherwise false
 func IsEnd() bool {
 	return bool(C.isendwin())
@@ -189,21 +400,67 @@ func IsTermResized(nlines, ncols int) bool {
 	return bool(C.is_term_resized(C.int(nlines), C.int(ncols)))
 }
 
-// Returns a string representing the value of input returned by Getch
+// KeyName returns the name curses gives to the key, such as "KEY_DOWN" for
+// a function key or "^I" for a control character, or an empty string if
+// the key has no name. Unlike KeyString, the names are those used by the
+// terminfo database and so cover every key curses knows about.
+func KeyName(k Key) string {
+	return C.GoString(C.keyname(C.int(k)))
+}
+
+// Returns a string representing the value of input returned by Getch. Keys
+// without a name of their own fall back to KeyName for function keys and
+// control characters, or the character itself otherwise.
 func KeyString(k Key) string {
 	key, ok := keyList[k]
 	if !ok {
-		key = fmt.Sprintf("%c", int(k))
+		if k < ' ' || k == 0x7f || k > 0xff {
+			key = KeyName(k)
+		}
+		if key == "" {
+			key = fmt.Sprintf("%c", int(k))
+		}
 	}
 	return key
 }
 
+// keyCodes is the reverse of keyList. Where more than one key shares the
+// same name, such as "enter", the lowest key value is used
+var keyCodes = make(map[string]Key, len(keyList))
+
+func init() {
+	for k, name := range keyList {
+		if code, ok := keyCodes[name]; !ok || k < code {
+			keyCodes[name] = k
+		}
+	}
+}
+
+// KeyCode is the reverse of KeyString. It returns the key represented by
+// the given name and true, or false if the name is not known. Names which
+// are a single character return the value of that character.
+func KeyCode(name string) (Key, bool) {
+	if k, ok := keyCodes[name]; ok {
+		return k, true
+	}
+	if r := []rune(name); len(r) == 1 {
+		return Key(r[0]), true
+	}
+	return 0, false
+}
+
+// LongName returns a verbose description of the current terminal, such as
+// "DEC VT52". It must be called after Init or NewTerm.
+func LongName() string {
+	return C.GoString(C.longname())
+}
+
 // PairContent returns the current foreground and background colours
 // associated with the given pair
 func PairContent(pair int16) (fg int16, bg int16, err error) {
 	var f, b C.short
 	if C.pair_content(C.short(pair), &f, &b) == C.ERR {
-		return -1, -1, errors.New("Invalid color pair")
+		return -1, -1, cursesError("pair_content")
 	}
 	return int16(f), int16(b), nil
 }
@@ -213,7 +470,11 @@ func Nap(ms int) {
 	C.napms(C.int(ms))
 }
 
-// NewLines turns newline translation on/off.
+// NewLines turns newline translation on/off. When on, which is the default,
+// the return key is read by GetChar as a newline ('\n') and newlines are
+// output as a carriage return and line feed. Turning it off allows the
+// return key ('\r') to be distinguished from Ctrl-J ('\n') and lets curses
+// make better use of the line feed capability when moving the cursor.
 func NewLines(on bool) {
 	if on {
 		C.nl()
@@ -226,6 +487,7 @@ func NewLines(on bool) {
 // are passed directly to input. Set to false if you wish to turn this mode
 // off
 func Raw(on bool) {
+	halfDelay = 0
 	if on {
 		C.raw()
 		return
@@ -233,11 +495,103 @@ func Raw(on bool) {
 	C.noraw()
 }
 
+// ResetProgMode restores the terminal to the "program" (in curses) state
+// saved by DefProgMode. See DefProgMode for details.
+func ResetProgMode() error {
+	if C.reset_prog_mode() == C.ERR {
+		return cursesError("reset_prog_mode")
+	}
+	return nil
+}
+
+// ResetShellMode restores the terminal to the "shell" (not in curses) state
+// saved by DefShellMode, which is normally the state the terminal was in
+// before Init was called.
+func ResetShellMode() error {
+	if C.reset_shell_mode() == C.ERR {
+		return cursesError("reset_shell_mode")
+	}
+	return nil
+}
+
+// ResetTTY restores the terminal driver settings saved by the last call to
+// SaveTTY
+func ResetTTY() error {
+	if C.resetty() == C.ERR {
+		return cursesError("resetty")
+	}
+	return nil
+}
+
 // ResizeTerm will attempt to resize the terminal. This only has an effect if
 // the terminal is in an XWindows (GUI) environment.
 func ResizeTerm(nlines, ncols int) error {
 	if C.resizeterm(C.int(nlines), C.int(ncols)) == C.ERR {
-		return errors.New("Failed to resize terminal")
+		return cursesError("resizeterm")
+	}
+	return nil
+}
+
+// Run initializes curses, calls fn with the standard window and then ends
+// curses, returning any error from Init or fn. End is called even if fn
+// panics, so the terminal is restored before the panic is reported and the
+// stack trace is readable. This is the simplest way to ensure End is never
+// forgotten.
+func Run(fn func(stdscr *Window) error) error {
+	return run(Init, fn)
+}
+
+// run implements Run using init to initialize curses, which allows a screen
+// created by NewTerm to be used in its place
+func run(init func() (*Window, error), fn func(*Window) error) error {
+	stdscr, err := init()
+	if err != nil {
+		return err
+	}
+	defer End()
+	return fn(stdscr)
+}
+
+// SaveTTY saves the current terminal driver settings, such as those changed
+// by Echo, Raw or CBreak, so they can be restored exactly by ResetTTY.
+// Unlike DefProgMode and DefShellMode, which save the modes for being in
+// and out of curses, SaveTTY is intended for temporary changes made while
+// in curses mode.
+func SaveTTY() error {
+	if C.savetty() == C.ERR {
+		return cursesError("savetty")
+	}
+	return nil
+}
+
+// SetEscDelay sets the time, in milliseconds, curses waits after receiving
+// an escape for the rest of an escape sequence before returning the escape
+// as a key press on its own. The default is one second, which makes the
+// escape key feel sluggish. A short delay, such as 25ms, makes it respond
+// almost instantly on a local terminal but risks splitting the sequence of
+// a function key over a slow or high-latency connection into separate key
+// presses. The ESCDELAY environment variable, if set, overrides the default.
+// This only applies when Keypad is enabled.
+func SetEscDelay(ms int) error {
+	if ms < 0 {
+		return errors.New("Escape delay must not be negative")
+	}
+	if C.ncurses_set_escdelay(C.int(ms)) == C.ERR {
+		return cursesError("set_escdelay")
+	}
+	return nil
+}
+
+// SetTabSize sets the number of columns between tab stops, which is
+// initially eight. Tabs are expanded to spaces as they are written, so the
+// new size applies to all subsequent output to any window of the current
+// screen but text already on screen is not changed.
+func SetTabSize(size int) error {
+	if size <= 0 {
+		return errors.New("Tab size must be greater than zero")
+	}
+	if C.ncurses_set_tabsize(C.int(size)) == C.ERR {
+		return cursesError("set_tabsize")
 	}
 	return nil
 }
@@ -249,8 +603,9 @@ func StartColor() error {
 		return errors.New("Terminal does not support colors")
 	}
 	if C.start_color() == C.ERR {
-		return errors.New("Failed to enable color mode")
+		return cursesError("start_color")
 	}
+	pairs = make(map[[2]int16]int)
 	return nil
 }
 
@@ -258,7 +613,47 @@ func StartColor() error {
 // the physical screen. This is the same Window returned by Init and therefore
 // not useful unless using NewTerm and other multi-screen related functions.
 func StdScr() *Window {
-	return &Window{C.stdscr}
+	return &Window{win: C.stdscr}
+}
+
+// TabSize returns the number of columns between tab stops. See SetTabSize.
+func TabSize() int {
+	return int(C.ncurses_tabsize())
+}
+
+// TermAttrs returns the video attributes, such as A_BOLD and A_REVERSE,
+// which the terminal supports. Attributes not in the list may still be
+// used but will not be displayed or will be approximated. This can be used
+// to pick the best available way to emphasise text.
+func TermAttrs() []Char {
+	supported := Char(C.termattrs())
+	var attrs []Char
+	for _, attr := range []Char{A_STANDOUT, A_UNDERLINE, A_REVERSE, A_BLINK,
+		A_DIM, A_BOLD, A_PROTECT, A_INVIS, A_ALTCHARSET} {
+		if supported&attr != 0 {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// TermName returns the short name of the current terminal as loaded by
+// curses, such as "xterm", which may differ from $TERM if a terminal type
+// was passed to NewTerm. It must be called after Init or NewTerm.
+func TermName() string {
+	return C.GoString(C.termname())
+}
+
+// TypeAhead sets the file descriptor which curses checks for pending input
+// while updating the screen. If input is waiting, curses postpones the
+// update so that it can respond to the input sooner. The input file is used
+// by default; pass -1 to disable the check altogether, which can help on
+// slow or high-latency connections where the check itself is costly.
+func TypeAhead(fd int) error {
+	if C.typeahead(C.int(fd)) == C.ERR {
+		return cursesError("typeahead")
+	}
+	return nil
 }
 
 // UnGetChar places the character back into the input queue
@@ -266,10 +661,11 @@ func UnGetChar(ch Char) {
 	C.ncurses_ungetch(C.int(ch))
 }
 
-// Update the screen, refreshing all windows
+// Update the screen, refreshing all windows. Update outputs all changes
+// flagged by calls to Window.NoutRefresh in a single operation
 func Update() error {
 	if C.doupdate() == C.ERR {
-		return errors.New("Failed to update")
+		return cursesError("doupdate")
 	}
 	return nil
 }
@@ -281,7 +677,7 @@ func Update() error {
 // does not support certain ncurses features like orig_pair or initialize_pair.
 func UseDefaultColors() error {
 	if C.use_default_colors() == C.ERR {
-		return errors.New("Failed to assume default colours.")
+		return cursesError("use_default_colors")
 	}
 	return nil
 }
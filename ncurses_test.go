@@ -0,0 +1,485 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestKeyString(t *testing.T) {
+	tests := []struct {
+		key  goncurses.Key
+		name string
+	}{
+		{goncurses.KEY_DC, "delete"},
+		{goncurses.KEY_IC, "insert"},
+		{goncurses.KEY_PAGEDOWN, "page down"},
+		{goncurses.KEY_PAGEUP, "page up"},
+		{goncurses.KEY_END, "end"},
+		{goncurses.KEY_RESIZE, "resize"},
+		{goncurses.KEY_BTAB, "shift tab"},
+		{goncurses.KEY_A1, "keypad upper left"},
+		{goncurses.KEY_C3, "keypad lower right"},
+		{goncurses.KEY_F12, "F12"},
+		{goncurses.KEY_F13, "F13"},
+		{goncurses.KEY_F24, "F24"},
+		{goncurses.KEY_UNDO, "KEY_UNDO"},
+		{0x01, "^A"},
+		{'a', "a"},
+		{'é', "é"},
+	}
+	for _, test := range tests {
+		if name := goncurses.KeyString(test.key); name != test.name {
+			t.Errorf("key %d: expected %q, got %q", test.key, test.name,
+				name)
+		}
+	}
+}
+
+func TestKeyName(t *testing.T) {
+	tests := []struct {
+		key  goncurses.Key
+		name string
+	}{
+		{goncurses.KEY_DOWN, "KEY_DOWN"},
+		{goncurses.KEY_F1, "KEY_F(1)"},
+		{'\t', "^I"},
+		{'a', "a"},
+	}
+	for _, test := range tests {
+		if name := goncurses.KeyName(test.key); name != test.name {
+			t.Errorf("key %d: expected %q, got %q", test.key, test.name,
+				name)
+		}
+	}
+}
+
+func TestKeyCode(t *testing.T) {
+	tests := []struct {
+		name string
+		key  goncurses.Key
+	}{
+		{"down", goncurses.KEY_DOWN},
+		{"enter", goncurses.KEY_RETURN},
+		{"F13", goncurses.KEY_F13},
+		{"q", 'q'},
+	}
+	for _, test := range tests {
+		k, ok := goncurses.KeyCode(test.name)
+		if !ok || k != test.key {
+			t.Errorf("%q: expected %d, got %d (%v)", test.name, test.key, k,
+				ok)
+		}
+	}
+	if _, ok := goncurses.KeyCode("no such key"); ok {
+		t.Error("expected unknown key name to not be found")
+	}
+}
+
+func TestACS(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	for _, ch := range []goncurses.Char{goncurses.ACS_HLINE,
+		goncurses.ACS_VLINE, goncurses.ACS_ULCORNER} {
+		if goncurses.ACS(ch)&goncurses.A_CHARTEXT == 0 {
+			t.Errorf("expected %#x to map to a character", ch)
+		}
+	}
+}
+
+func TestCursesError(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(5, 5, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	err = win.MoveWindow(100, 100)
+	var cerr *goncurses.CursesError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a CursesError, got %v", err)
+	}
+	if cerr.Func != "mvwin" {
+		t.Errorf("expected mvwin to have failed, got %s", cerr.Func)
+	}
+	if s := err.Error(); s != "goncurses: mvwin failed" {
+		t.Errorf("unexpected error message %q", s)
+	}
+}
+
+func TestInitPairBadColor(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	if err := goncurses.InitPair(1, 1000, goncurses.C_BLACK); err !=
+		goncurses.ErrBadFGColor {
+		t.Errorf("expected ErrBadFGColor, got %v", err)
+	}
+	if err := goncurses.InitPair(1, goncurses.C_WHITE, 1000); err !=
+		goncurses.ErrBadBGColor {
+		t.Errorf("expected ErrBadBGColor, got %v", err)
+	}
+	if err := goncurses.InitPair(1, goncurses.C_WHITE,
+		goncurses.C_BLACK); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTypeAhead(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.TypeAhead(-1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if _, err := goncurses.Cursor(1); err != nil {
+		t.Fatal(err)
+	}
+	prev, err := goncurses.Cursor(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != 1 {
+		t.Errorf("expected previous visibility 1, got %d", prev)
+	}
+	if prev, _ = goncurses.Cursor(byte(prev)); prev != 0 {
+		t.Errorf("expected previous visibility 0, got %d", prev)
+	}
+}
+
+func TestHasKey(t *testing.T) {
+	stdscr, end := newTestTerm(t, "vt52")
+	defer end()
+
+	stdscr.Keypad(true)
+	if !goncurses.HasKey(goncurses.KEY_UP) {
+		t.Error("expected vt52 to have an up arrow key")
+	}
+	if goncurses.HasKey(goncurses.KEY_HOME) {
+		t.Error("expected vt52 to have no home key")
+	}
+}
+
+func TestTermAttrs(t *testing.T) {
+	for _, test := range []struct {
+		term  string
+		attr  goncurses.Char
+		found bool
+	}{
+		{"xterm", goncurses.A_BOLD, true},
+		{"xterm", goncurses.A_REVERSE, true},
+		{"vt52", goncurses.A_BOLD, false},
+	} {
+		_, end := newTestTerm(t, test.term)
+		found := false
+		for _, attr := range goncurses.TermAttrs() {
+			found = found || attr == test.attr
+		}
+		if found != test.found {
+			t.Errorf("%s: expected attribute %#x supported to be %v",
+				test.term, test.attr, test.found)
+		}
+		end()
+	}
+}
+
+func TestTermName(t *testing.T) {
+	_, end := newTestTerm(t, "vt52")
+	defer end()
+
+	if name := goncurses.TermName(); name != "vt52" {
+		t.Errorf("expected terminal name vt52, got %q", name)
+	}
+	if name := goncurses.LongName(); name != "DEC VT52" {
+		t.Errorf("expected long name \"DEC VT52\", got %q", name)
+	}
+}
+
+func TestSetTabSize(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if n := goncurses.TabSize(); n != 8 {
+		t.Errorf("expected default tab size of 8, got %d", n)
+	}
+	if err := goncurses.SetTabSize(4); err != nil {
+		t.Fatal(err)
+	}
+	defer goncurses.SetTabSize(8)
+	if n := goncurses.TabSize(); n != 4 {
+		t.Errorf("expected tab size of 4, got %d", n)
+	}
+	stdscr.Print("a\tb")
+	if _, x := stdscr.CursorYX(); x != 5 {
+		t.Errorf("expected cursor at column 5, got %d", x)
+	}
+	if err := goncurses.SetTabSize(0); err == nil {
+		t.Error("expected error for tab size of zero")
+	}
+}
+
+func TestCursesVersion(t *testing.T) {
+	if v := goncurses.CursesVersion(); !strings.HasPrefix(v, "ncurses ") {
+		t.Errorf("expected version to start with \"ncurses \", got %q", v)
+	}
+}
+
+func TestSetEscDelay(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	prev := goncurses.EscDelay()
+	defer goncurses.SetEscDelay(prev)
+	if err := goncurses.SetEscDelay(25); err != nil {
+		t.Fatal(err)
+	}
+	if d := goncurses.EscDelay(); d != 25 {
+		t.Errorf("expected escape delay of 25, got %d", d)
+	}
+	if err := goncurses.SetEscDelay(-1); err == nil {
+		t.Error("expected error for negative delay")
+	}
+}
+
+func TestAllocPair(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	red, err := goncurses.AllocPair(goncurses.C_RED, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blue, err := goncurses.AllocPair(goncurses.C_BLUE, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if red == blue {
+		t.Error("expected different pairs for different colors")
+	}
+	if pair, _ := goncurses.AllocPair(goncurses.C_RED,
+		goncurses.C_BLACK); pair != red {
+		t.Errorf("expected existing pair %d to be reused, got %d", red, pair)
+	}
+	if fg, bg, _ := goncurses.PairContent(blue); fg != goncurses.C_BLUE ||
+		bg != goncurses.C_BLACK {
+		t.Errorf("expected pair %d to be blue on black, got %d, %d", blue,
+			fg, bg)
+	}
+	if _, err := goncurses.AllocPair(1000, goncurses.C_BLACK); err !=
+		goncurses.ErrBadFGColor {
+		t.Errorf("expected ErrBadFGColor, got %v", err)
+	}
+
+	// xterm has 64 pairs, one fewer than needed for every combination of
+	// its 8 colors when pair 0 is excluded
+	err = nil
+	for fg := int16(0); fg < 8 && err == nil; fg++ {
+		for bg := int16(0); bg < 8 && err == nil; bg++ {
+			_, err = goncurses.AllocPair(fg, bg)
+		}
+	}
+	if err == nil {
+		t.Error("expected error once all color pairs were allocated")
+	}
+}
+
+func TestAllocPairN(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	pair, err := goncurses.AllocPairN(goncurses.C_GREEN, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := goncurses.AllocPairN(goncurses.C_GREEN,
+		goncurses.C_BLACK); p != pair {
+		t.Errorf("expected existing pair %d to be reused, got %d", pair, p)
+	}
+	if fg, bg, _ := goncurses.PairContent(int16(pair)); fg !=
+		goncurses.C_GREEN || bg != goncurses.C_BLACK {
+		t.Errorf("expected pair %d to be green on black, got %d, %d", pair,
+			fg, bg)
+	}
+	if err := goncurses.FreePair(pair); err != nil {
+		t.Fatal(err)
+	}
+	if err := goncurses.FreePair(100000); err == nil {
+		t.Error("expected error freeing a pair which does not exist")
+	}
+}
+
+func TestBatch(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "term")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	in, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	scr, err := goncurses.NewTerm("xterm", out, in)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer scr.Delete()
+	defer scr.End()
+	stdscr := goncurses.StdScr()
+	stdscr.Refresh()
+
+	written := func() int64 {
+		fi, err := out.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Size()
+	}
+	before := written()
+	err = goncurses.Batch(func() {
+		stdscr.MovePrint(0, 0, "outer")
+		stdscr.Refresh()
+		goncurses.Batch(func() {
+			stdscr.MovePrint(1, 0, "inner")
+			stdscr.Refresh()
+		})
+		if n := written(); n != before {
+			t.Errorf("expected no output within batch, got %d bytes",
+				n-before)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written() == before {
+		t.Error("expected output once the batch completed")
+	}
+}
+
+func TestAllocPairMixed(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	red, err := goncurses.AllocPair(goncurses.C_RED, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	green, err := goncurses.AllocPairN(goncurses.C_GREEN, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blue, err := goncurses.AllocPair(goncurses.C_BLUE, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(red) == green || int(blue) == green || red == blue {
+		t.Errorf("expected distinct pairs, got %d, %d, %d", red, green, blue)
+	}
+	if fg, _, _ := goncurses.PairContent(int16(green)); fg !=
+		goncurses.C_GREEN {
+		t.Errorf("expected pair %d to remain green, got %d", green, fg)
+	}
+	if p, _ := goncurses.AllocPairN(goncurses.C_RED,
+		goncurses.C_BLACK); p != int(red) {
+		t.Errorf("expected AllocPairN to reuse pair %d, got %d", red, p)
+	}
+
+	// a freed pair is forgotten and can be allocated again
+	if err := goncurses.FreePair(int(red)); err != nil {
+		t.Fatal(err)
+	}
+	if err := goncurses.FreePair(int(red)); err == nil {
+		t.Error("expected error freeing a pair twice")
+	}
+	pair, err := goncurses.AllocPair(goncurses.C_CYAN, goncurses.C_BLACK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fg, _, _ := goncurses.PairContent(pair); fg != goncurses.C_CYAN {
+		t.Errorf("expected pair %d to be cyan, got %d", pair, fg)
+	}
+}
+
+func TestRun(t *testing.T) {
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	in, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	var scr *goncurses.Screen
+	init := func() (*goncurses.Window, error) {
+		if scr, err = goncurses.NewTerm("xterm", out, in); err != nil {
+			return nil, err
+		}
+		return goncurses.StdScr(), nil
+	}
+
+	errFn := errors.New("fn failed")
+	err = goncurses.RunWith(init, func(stdscr *goncurses.Window) error {
+		if goncurses.IsEnd() {
+			t.Error("expected curses to be running within fn")
+		}
+		return errFn
+	})
+	if scr == nil {
+		t.Skip(err)
+	}
+	if err != errFn {
+		t.Errorf("expected the error from fn, got %v", err)
+	}
+	if !goncurses.IsEnd() {
+		t.Error("expected End to be called after fn returned")
+	}
+	scr.Delete()
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected the panic to propagate, got %v", r)
+			}
+		}()
+		goncurses.RunWith(init, func(stdscr *goncurses.Window) error {
+			panic("boom")
+		})
+	}()
+	if !goncurses.IsEnd() {
+		t.Error("expected End to be called after fn panicked")
+	}
+	scr.Delete()
+}
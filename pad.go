@@ -9,8 +9,6 @@ package goncurses
 // #include "goncurses.h"
 import "C"
 
-import "errors"
-
 type Pad struct {
 	*Window
 }
@@ -22,9 +20,9 @@ type Pad struct {
 func NewPad(h, w int) (*Pad, error) {
 	p := C.newpad(C.int(h), C.int(w))
 	if p == nil {
-		return nil, errors.New("Failed to create pad")
+		return nil, cursesError("newpad")
 	}
-	return &Pad{&Window{p}}, nil
+	return &Pad{&Window{win: p}}, nil
 }
 
 // NoutRefresh indicates that a section of the screen should be redrawn but
@@ -35,7 +33,7 @@ func (p *Pad) NoutRefresh(py, px, sy, sx, h, w int) error {
 	ok := C.pnoutrefresh(p.win, C.int(py), C.int(px), C.int(sy),
 		C.int(sx), C.int(h), C.int(w))
 	if ok != C.OK {
-		return errors.New("Failed to refresh pad")
+		return cursesError("pnoutrefresh")
 	}
 	return nil
 }
@@ -51,7 +49,7 @@ func (p *Pad) NoutRefresh(py, px, sy, sx, h, w int) error {
 func (p *Pad) Refresh(py, px, sy, sx, h, w int) error {
 	if C.prefresh(p.win, C.int(py), C.int(px), C.int(sy), C.int(sx),
 		C.int(h), C.int(w)) != C.OK {
-		return errors.New("Failed to refresh pad")
+		return cursesError("prefresh")
 	}
 	return nil
 }
@@ -59,6 +57,6 @@ func (p *Pad) Refresh(py, px, sy, sx, h, w int) error {
 // Sub creates a sub-pad h(eight) by w(idth) in size starting at the location
 // y, x in the parent pad. Changes to a sub-pad will also change it's parent
 func (p *Pad) Sub(y, x, h, w int) *Pad {
-	return &Pad{&Window{C.subpad(p.win, C.int(h), C.int(w), C.int(y),
+	return &Pad{&Window{win: C.subpad(p.win, C.int(h), C.int(w), C.int(y),
 		C.int(x))}}
 }
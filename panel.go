@@ -4,27 +4,29 @@
 
 package goncurses
 
-// #cgo !windows pkg-config: panel
+// #cgo !windows pkg-config: panelw
 // #include <panel.h>
 // #include <curses.h>
 import "C"
 
-import "errors"
-
 type Panel struct {
 	pan *C.PANEL
 }
 
-// Panel creates a new panel derived from the window, adding it to the
-// panel stack. The pointer to the original window can still be used to
-// excute most window functions with the exception of Refresh(). Always
+// Panel creates a new panel derived from the window, adding it to the top
+// of the panel stack. The pointer to the original window can still be used
+// to excute most window functions with the exception of Refresh(). Always
 // use panel's Refresh() function.
+//
+// The panel stack keeps overlapping windows in z-order. Use Top, Bottom,
+// Hide and Show to rearrange it, then call UpdatePanels followed by Update
+// to draw every visible panel, bottom to top, so that the topmost wins.
 func NewPanel(w *Window) *Panel {
 	return &Panel{C.new_panel(w.win)}
 }
 
 // UpdatePanels refreshes the panel stack. It must be called prior to
-// using ncurses's DoUpdate()
+// calling Update()
 func UpdatePanels() {
 	C.update_panels()
 	return
@@ -45,7 +47,7 @@ func Below(p *Panel) *Panel {
 // Move the panel to the bottom of the stack.
 func (p *Panel) Bottom() error {
 	if C.bottom_panel(p.pan) == C.ERR {
-		return errors.New("Failed to move panel to bottom of stack")
+		return cursesError("bottom_panel")
 	}
 	return nil
 }
@@ -53,7 +55,7 @@ func (p *Panel) Bottom() error {
 // Delete panel, removing from the stack.
 func (p *Panel) Delete() error {
 	if C.del_panel(p.pan) == C.ERR {
-		return errors.New("Failed to delete panel")
+		return cursesError("del_panel")
 	}
 	p = nil
 	return nil
@@ -67,7 +69,7 @@ func (p *Panel) Hidden() bool {
 // Hide the panel
 func (p *Panel) Hide() error {
 	if C.hide_panel(p.pan) == C.ERR {
-		return errors.New("Failed to hide panel")
+		return cursesError("hide_panel")
 	}
 	return nil
 }
@@ -77,7 +79,7 @@ func (p *Panel) Hide() error {
 // this function
 func (p *Panel) Move(y, x int) error {
 	if C.move_panel(p.pan, C.int(y), C.int(x)) == C.ERR {
-		return errors.New("Failed to move panel")
+		return cursesError("move_panel")
 	}
 	return nil
 }
@@ -85,7 +87,7 @@ func (p *Panel) Move(y, x int) error {
 // Replace panel's associated window with a new one.
 func (p *Panel) Replace(w *Window) error {
 	if C.replace_panel(p.pan, w.win) == C.ERR {
-		return errors.New("Failed to replace window")
+		return cursesError("replace_panel")
 	}
 	return nil
 }
@@ -93,7 +95,7 @@ func (p *Panel) Replace(w *Window) error {
 // Show the panel, if hidden, and place it on the top of the stack.
 func (p *Panel) Show() error {
 	if C.show_panel(p.pan) == C.ERR {
-		return errors.New("Failed to show panel")
+		return cursesError("show_panel")
 	}
 	return nil
 }
@@ -101,12 +103,12 @@ func (p *Panel) Show() error {
 // Move panel to the top of the stack
 func (p *Panel) Top() error {
 	if C.top_panel(p.pan) == C.ERR {
-		return errors.New("Failed to move panel to top of stack")
+		return cursesError("top_panel")
 	}
 	return nil
 }
 
 // Window returns the window governed by panel
 func (p *Panel) Window() *Window {
-	return &Window{C.panel_window(p.pan)}
+	return &Window{win: C.panel_window(p.pan)}
 }
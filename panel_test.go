@@ -0,0 +1,48 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestPanelStack(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	var panels []*goncurses.Panel
+	for i, ch := range []goncurses.Char{'a', 'b'} {
+		win, err := goncurses.NewWindow(3, 3, i, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer win.Delete()
+		win.Fill(0, 0, 3, 3, ch)
+		p := goncurses.NewPanel(win)
+		defer p.Delete()
+		panels = append(panels, p)
+	}
+	lower, upper := panels[0], panels[1]
+
+	above := func(p *goncurses.Panel) goncurses.Char {
+		return p.Above().Window().MoveInChar(0, 0) & goncurses.A_CHARTEXT
+	}
+	if ch := above(lower); ch != 'b' {
+		t.Errorf("expected newest panel on top, got %q", rune(ch))
+	}
+	if err := lower.Top(); err != nil {
+		t.Fatal(err)
+	}
+	if ch := above(upper); ch != 'a' {
+		t.Errorf("expected raised panel on top, got %q", rune(ch))
+	}
+	goncurses.UpdatePanels()
+	if err := goncurses.Update(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,194 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses
+
+import "unicode/utf8"
+
+// ReadLineOptions control the behaviour of Window.ReadLine
+type ReadLineOptions struct {
+	// Width is the number of columns used to display the line. Text longer
+	// than the width is scrolled horizontally. Zero uses the remainder of
+	// the current line of the window.
+	Width int
+
+	// Max is the maximum number of characters which may be entered, or zero
+	// for no limit.
+	Max int
+
+	// Initial is the text the line starts with, which the user may then
+	// edit. It is truncated to Max characters.
+	Initial string
+
+	// History holds previously entered lines, oldest first, which can be
+	// recalled with the up and down arrow keys.
+	History []string
+}
+
+// ReadLine reads a line of input entered by the user at the cursor,
+// providing basic line editing. The left and right arrow keys move the
+// cursor, Home or Ctrl-A and End or Ctrl-E move to the start and end of the
+// line, Backspace and Delete remove characters, Ctrl-U clears the line and
+// Enter completes the input. Keypad and echo are set as required while
+// reading and then restored.
+func (w *Window) ReadLine(opts ReadLineOptions) (string, error) {
+	prev := echo
+	Echo(false)
+	defer Echo(prev)
+	if !w.IsKeypad() {
+		w.Keypad(true)
+		defer w.Keypad(false)
+	}
+
+	y, x := w.CursorYX()
+	my, mx := w.MaxYX()
+	width := opts.Width
+	if width <= 0 || x+width > mx {
+		width = mx - x
+	}
+	if y == my-1 && x+width == mx {
+		// writing to the bottom right corner would scroll the window
+		width--
+	}
+	l := lineEditor{win: w, y: y, x: x, width: width}
+	l.set(opts.Initial)
+	if opts.Max > 0 && len(l.buf) > opts.Max {
+		l.buf = l.buf[:opts.Max]
+		l.pos = opts.Max
+	}
+	hist, saved := len(opts.History), ""
+
+	var pending []byte
+	for {
+		l.draw()
+		k, err := w.ReadChar()
+		if err != nil {
+			return string(l.buf), err
+		}
+		switch k {
+		case '\n', '\r', KEY_ENTER:
+			return string(l.buf), nil
+		case KEY_LEFT:
+			l.move(l.pos - 1)
+		case KEY_RIGHT:
+			l.move(l.pos + 1)
+		case KEY_HOME, 0x01:
+			l.move(0)
+		case KEY_END, 0x05:
+			l.move(len(l.buf))
+		case KEY_BACKSPACE, 0x7f, '\b':
+			if l.pos > 0 {
+				l.pos--
+				l.delete()
+			}
+		case KEY_DC:
+			l.delete()
+		case 0x15:
+			l.set("")
+		case KEY_UP:
+			if hist > 0 {
+				if hist == len(opts.History) {
+					saved = string(l.buf)
+				}
+				hist--
+				l.set(opts.History[hist])
+			}
+		case KEY_DOWN:
+			if hist < len(opts.History) {
+				hist++
+				if hist == len(opts.History) {
+					l.set(saved)
+				} else {
+					l.set(opts.History[hist])
+				}
+			}
+		default:
+			if k < ' ' || k > 0xff {
+				continue
+			}
+			pending = append(pending, byte(k))
+			if !utf8.FullRune(pending) {
+				continue
+			}
+			r, _ := utf8.DecodeRune(pending)
+			pending = pending[:0]
+			if opts.Max > 0 && len(l.buf) >= opts.Max {
+				Beep()
+				continue
+			}
+			l.insert(r)
+		}
+	}
+}
+
+// EditString reads a line of at most n characters, or any length if n is
+// zero, starting with initial already entered and the cursor at its end.
+// Pressing Enter straight away keeps the initial value. See ReadLine for the
+// editing keys available.
+func (w *Window) EditString(n int, initial string) (string, error) {
+	return w.ReadLine(ReadLineOptions{Max: n, Initial: initial})
+}
+
+// lineEditor holds the state of a line being edited by ReadLine
+type lineEditor struct {
+	win    *Window
+	y, x   int // origin of the line in the window
+	width  int // number of columns used to display the line
+	buf    []rune
+	pos    int // index of the cursor in buf
+	offset int // index of the first character displayed
+}
+
+// move the cursor to pos, clamped to the bounds of the line
+func (l *lineEditor) move(pos int) {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(l.buf) {
+		pos = len(l.buf)
+	}
+	l.pos = pos
+}
+
+// set replaces the line with s and moves the cursor to its end
+func (l *lineEditor) set(s string) {
+	l.buf = []rune(s)
+	l.pos = len(l.buf)
+}
+
+// insert r at the cursor
+func (l *lineEditor) insert(r rune) {
+	l.buf = append(l.buf, 0)
+	copy(l.buf[l.pos+1:], l.buf[l.pos:])
+	l.buf[l.pos] = r
+	l.pos++
+}
+
+// delete the character at the cursor
+func (l *lineEditor) delete() {
+	if l.pos < len(l.buf) {
+		l.buf = append(l.buf[:l.pos], l.buf[l.pos+1:]...)
+	}
+}
+
+// draw the visible portion of the line, scrolling it horizontally so that
+// the cursor is always in view, and position the window's cursor
+func (l *lineEditor) draw() {
+	if l.pos < l.offset {
+		l.offset = l.pos
+	}
+	if l.pos >= l.offset+l.width {
+		l.offset = l.pos - l.width + 1
+	}
+	line := make([]rune, l.width)
+	for i := range line {
+		line[i] = ' '
+		if l.offset+i < len(l.buf) {
+			line[i] = l.buf[l.offset+i]
+		}
+	}
+	l.win.moveAddString(l.y, l.x, string(line))
+	l.win.Move(l.y, l.x+l.pos-l.offset)
+}
@@ -0,0 +1,108 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+// unGetKeys pushes keys onto the input queue so that they are read in order
+func unGetKeys(keys ...goncurses.Char) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		goncurses.UnGetChar(keys[i])
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	tests := []struct {
+		keys []goncurses.Char
+		opts goncurses.ReadLineOptions
+		line string
+	}{
+		{[]goncurses.Char{'a', 'b', 'c', '\n'},
+			goncurses.ReadLineOptions{}, "abc"},
+		{[]goncurses.Char{'h', 'e', 'l', 'l', 'o', goncurses.KEY_LEFT,
+			goncurses.KEY_LEFT, 'X', goncurses.KEY_HOME, goncurses.KEY_DC,
+			goncurses.KEY_END, goncurses.KEY_BACKSPACE, '\n'},
+			goncurses.ReadLineOptions{}, "elXl"},
+		{[]goncurses.Char{'a', 'b', 'c', 0x15, 'd', '\n'},
+			goncurses.ReadLineOptions{}, "d"},
+		{[]goncurses.Char{'a', 'b', 'c', '\n'},
+			goncurses.ReadLineOptions{Max: 2}, "ab"},
+		{[]goncurses.Char{'x', goncurses.KEY_UP, goncurses.KEY_UP,
+			goncurses.KEY_DOWN, '\n'},
+			goncurses.ReadLineOptions{History: []string{"one", "two"}},
+			"two"},
+		{[]goncurses.Char{'x', goncurses.KEY_UP, goncurses.KEY_DOWN, '\n'},
+			goncurses.ReadLineOptions{History: []string{"one"}}, "x"},
+	}
+	for i, test := range tests {
+		stdscr.Move(i, 0)
+		unGetKeys(test.keys...)
+		line, err := stdscr.ReadLine(test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != test.line {
+			t.Errorf("%d: expected %q, got %q", i, test.line, line)
+		}
+	}
+	if stdscr.IsKeypad() {
+		t.Error("expected keypad to be restored")
+	}
+}
+
+func TestReadLineScroll(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Move(0, 2)
+	unGetKeys('a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', '\n')
+	line, err := stdscr.ReadLine(goncurses.ReadLineOptions{Width: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != "abcdefgh" {
+		t.Errorf("expected \"abcdefgh\", got %q", line)
+	}
+	if s := stdscr.MoveInString(0, 0, 8); s != "  efgh  " {
+		t.Errorf("expected line scrolled within its width, got %q", s)
+	}
+}
+
+func TestEditString(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	tests := []struct {
+		keys    []goncurses.Char
+		n       int
+		initial string
+		line    string
+	}{
+		{[]goncurses.Char{'\n'}, 0, "keep", "keep"},
+		{[]goncurses.Char{goncurses.KEY_BACKSPACE, 'X', '\n'}, 0, "abc",
+			"abX"},
+		{[]goncurses.Char{'d', '\n'}, 4, "abc", "abcd"},
+		{[]goncurses.Char{'d', '\n'}, 2, "abc", "ab"},
+	}
+	for i, test := range tests {
+		stdscr.Move(i, 0)
+		unGetKeys(test.keys...)
+		line, err := stdscr.EditString(test.n, test.initial)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != test.line {
+			t.Errorf("%d: expected %q, got %q", i, test.line, line)
+		}
+	}
+}
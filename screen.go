@@ -18,6 +18,10 @@ import (
 	"unsafe"
 )
 
+// Screen represents a terminal created by NewTerm. Each Screen has its own
+// StdScr and state, so an application may drive several terminals or a
+// test may run against a screen writing to any file. Only one Screen is
+// active at a time; use Set to switch between them.
 type Screen struct{ scrPtr *C.SCREEN }
 
 // NewTerm returns a new Screen, representing a physical terminal. If using
@@ -41,26 +45,38 @@ func NewTerm(termType string, out, in *os.File) (*Screen, error) {
 	defer C.free(unsafe.Pointer(wr))
 	defer C.free(unsafe.Pointer(rd))
 
+	setLocale()
 	cout, cin := C.fdopen(C.int(out.Fd()), wr), C.fdopen(C.int(in.Fd()), rd)
 	screen := C.newterm(tt, cout, cin)
 	if screen == nil {
-		return nil, errors.New("Failed to create new screen")
+		return nil, cursesError("newterm")
 	}
+	echo = true
 	return &Screen{screen}, nil
 }
 
-// Set the screen to be the current, active screen
+// Set makes the screen the current, active screen. Global functions and
+// StdScr operate on the active screen. The previously active screen is
+// returned.
 func (s *Screen) Set() (*Screen, error) {
 	screen := C.set_term(s.scrPtr)
 	if screen == nil {
-		return nil, errors.New("Failed to set screen")
+		return nil, cursesError("set_term")
 	}
 	return &Screen{screen}, nil
 }
 
-// Delete frees memory allocated to the screen. This function
-func (s *Screen) Delete() {
+// Delete frees memory allocated to the screen. It must be called after End
+// has been called for the screen. Neither the screen nor any of the windows
+// belonging to it, including its StdScr, may be used afterward and calling
+// Delete again will return an error.
+func (s *Screen) Delete() error {
+	if s.scrPtr == nil {
+		return errors.New("Screen already deleted")
+	}
 	C.delscreen(s.scrPtr)
+	s.scrPtr = nil
+	return nil
 }
 
 // End is just a wrapper for the global End function. This helper function
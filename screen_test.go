@@ -0,0 +1,50 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestScreenSet(t *testing.T) {
+	first, end := newTestScreen(t)
+	defer end()
+
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	in, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	scr, err := goncurses.NewTerm("vt52", out, in)
+	if err != nil {
+		t.Skip(err)
+	}
+	second := goncurses.StdScr()
+	if *second == *first {
+		t.Fatal("expected each screen to have its own StdScr")
+	}
+	first.MovePrint(0, 0, "first")
+	second.MovePrint(0, 0, "second")
+	if s := first.MoveInString(0, 0, 6); s != "first " {
+		t.Errorf("expected first screen unchanged, got %q", s)
+	}
+	scr.End()
+	if err := scr.Delete(); err != nil {
+		t.Error(err)
+	}
+	if err := scr.Delete(); err == nil {
+		t.Error("expected error deleting screen twice")
+	}
+}
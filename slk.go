@@ -9,10 +9,7 @@ package goncurses
 // #include <curses.h>
 import "C"
 
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
 type SlkFormat byte
 
@@ -33,10 +30,16 @@ const (
 
 // Initializes the soft-key labels with the given format; keys like the
 // F1-F12 keys on most keyboards. After a call to SlkRefresh a bar at the
-// bottom of the standard screen returned by Init will be displayed. This
-// function MUST be called prior to Init()
-func SlkInit(f SlkFormat) {
-	C.slk_init(C.int(f))
+// bottom of the standard screen returned by Init will be displayed.
+//
+// IMPORTANT: This function MUST be called prior to Init() or NewTerm(). The
+// line used by the labels is taken from the screen when it is initialized,
+// so calling it afterwards has no effect.
+func SlkInit(f SlkFormat) error {
+	if C.slk_init(C.int(f)) == C.ERR {
+		return cursesError("slk_init")
+	}
+	return nil
 }
 
 // SlkSet sets the 'labnum' text to the supplied 'label'. Labels must not
@@ -46,7 +49,7 @@ func SlkSet(labnum int, label string, just SlkJustify) error {
 	defer C.free(unsafe.Pointer(cstr))
 
 	if C.slk_set(C.int(labnum), (*C.char)(cstr), C.int(just)) == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized")
+		return cursesError("slk_set")
 	}
 	return nil
 }
@@ -55,7 +58,7 @@ func SlkSet(labnum int, label string, just SlkJustify) error {
 // SlkNoutRefresh because a Window.Refresh is likely to follow
 func SlkRefresh() error {
 	if C.slk_refresh() == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_refresh")
 	}
 	return nil
 }
@@ -63,7 +66,7 @@ func SlkRefresh() error {
 // SlkNoutFresh behaves like Window.NoutRefresh
 func SlkNoutRefresh() error {
 	if C.slk_noutrefresh() == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_noutrefresh")
 	}
 	return nil
 }
@@ -76,7 +79,7 @@ func SlkLabel(labnum int) string {
 // SlkClear removes the soft-key labels from the screen
 func SlkClear() error {
 	if C.slk_clear() == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_clear")
 	}
 	return nil
 }
@@ -84,7 +87,7 @@ func SlkClear() error {
 // SlkRestore restores the soft-key labels to the screen after an SlkClear()
 func SlkRestore() error {
 	if C.slk_restore() == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_restore")
 	}
 	return nil
 }
@@ -92,7 +95,7 @@ func SlkRestore() error {
 // SlkTouch behaves just like Window.Touch
 func SlkTouch() error {
 	if C.slk_touch() == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_touch")
 	}
 	return nil
 }
@@ -100,7 +103,7 @@ func SlkTouch() error {
 // SlkColor sets the color pair for the soft-keys
 func SlkColor(cp int16) error {
 	if C.slk_color(C.short(cp)) == C.ERR {
-		return errors.New("Invalid color pair or soft-keys not initialized.")
+		return cursesError("slk_color")
 	}
 	return nil
 }
@@ -114,7 +117,7 @@ func SlkAttribute() Char {
 // SlkSetAttribute sets the OR'd attributes to use
 func SlkSetAttribute(attr Char) error {
 	if C.slk_attrset(C.chtype(attr)) == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_attrset")
 	}
 	return nil
 }
@@ -122,7 +125,7 @@ func SlkSetAttribute(attr Char) error {
 // SlkAttributeOn turns on the given OR'd attributes without turning any off
 func SlkAttributeOn(attr Char) error {
 	if C.slk_attron(C.chtype(attr)) == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_attron")
 	}
 	return nil
 }
@@ -130,7 +133,7 @@ func SlkAttributeOn(attr Char) error {
 // SlkAttributeOff turns off the given OR'd attributes withoiut turning any on
 func SlkAttributeOff(attr Char) error {
 	if C.slk_attroff(C.chtype(attr)) == C.ERR {
-		return errors.New("Soft-keys or terminal not initialized.")
+		return cursesError("slk_attroff")
 	}
 	return nil
 }
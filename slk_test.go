@@ -0,0 +1,36 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestSlk(t *testing.T) {
+	if err := goncurses.SlkInit(goncurses.SLK_323); err != nil {
+		t.Fatal(err)
+	}
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.SlkSet(1, "Help", goncurses.SLK_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	if label := goncurses.SlkLabel(1); label != "Help" {
+		t.Errorf("expected label \"Help\", got %q", label)
+	}
+	if err := goncurses.SlkNoutRefresh(); err != nil {
+		t.Error(err)
+	}
+	if err := goncurses.SlkRefresh(); err != nil {
+		t.Error(err)
+	}
+	if err := goncurses.SlkClear(); err != nil {
+		t.Error(err)
+	}
+}
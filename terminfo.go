@@ -0,0 +1,65 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows
+
+package goncurses
+
+// #include <stdlib.h>
+// #include <curses.h>
+// #include <term.h>
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// TigetFlag returns the value of the boolean terminfo capability named cap,
+// such as "am", for the current terminal. False is returned if the terminal
+// does not have the capability. An error is returned if cap is not the name
+// of a boolean capability. Init or NewTerm must have been called first.
+func TigetFlag(cap string) (bool, error) {
+	cstr := C.CString(cap)
+	defer C.free(unsafe.Pointer(cstr))
+
+	res := C.tigetflag(cstr)
+	if res < 0 {
+		return false, errors.New("Not a boolean capability")
+	}
+	return res > 0, nil
+}
+
+// TigetNum returns the value of the numeric terminfo capability named cap,
+// such as "colors", for the current terminal. -1 is returned if the
+// terminal does not have the capability. An error is returned if cap is not
+// the name of a numeric capability. Init or NewTerm must have been called
+// first.
+func TigetNum(cap string) (int, error) {
+	cstr := C.CString(cap)
+	defer C.free(unsafe.Pointer(cstr))
+
+	res := C.tigetnum(cstr)
+	if res == -2 {
+		return 0, errors.New("Not a numeric capability")
+	}
+	return int(res), nil
+}
+
+// TigetStr returns the value of the string terminfo capability named cap,
+// such as "u7", for the current terminal. An empty string is returned if
+// the terminal does not have the capability. An error is returned if cap is
+// not the name of a string capability. Init or NewTerm must have been
+// called first.
+func TigetStr(cap string) (string, error) {
+	cstr := C.CString(cap)
+	defer C.free(unsafe.Pointer(cstr))
+
+	res := C.tigetstr(cstr)
+	if uintptr(unsafe.Pointer(res)) == ^uintptr(0) {
+		return "", errors.New("Not a string capability")
+	}
+	return C.GoString(res), nil
+}
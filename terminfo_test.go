@@ -0,0 +1,41 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows
+
+package goncurses_test
+
+import (
+	"testing"
+
+	"github.com/rthornton128/goncurses"
+)
+
+func TestTiget(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if s, err := goncurses.TigetStr("u7"); err != nil || s != "\x1b[6n" {
+		t.Errorf("expected u7 to be \"\\x1b[6n\", got %q, %v", s, err)
+	}
+	if n, err := goncurses.TigetNum("colors"); err != nil || n != 8 {
+		t.Errorf("expected 8 colors, got %d, %v", n, err)
+	}
+	if ok, err := goncurses.TigetFlag("am"); err != nil || !ok {
+		t.Errorf("expected am to be set, got %v, %v", ok, err)
+	}
+	if ok, err := goncurses.TigetFlag("hc"); err != nil || ok {
+		t.Errorf("expected hc to be unset, got %v, %v", ok, err)
+	}
+	if _, err := goncurses.TigetStr("colors"); err == nil {
+		t.Error("expected error for a numeric capability")
+	}
+	if _, err := goncurses.TigetNum("am"); err == nil {
+		t.Error("expected error for a boolean capability")
+	}
+	if _, err := goncurses.TigetFlag("u7"); err == nil {
+		t.Error("expected error for a string capability")
+	}
+}
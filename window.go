@@ -10,20 +10,31 @@ package goncurses
 import "C"
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 	"unsafe"
 )
 
 type Window struct {
 	win *C.WINDOW
+
+	// blinkFallback is set when blinking is being simulated for the window
+	// and blinkReverse when blinking characters are shown in reverse video
+	blinkFallback bool
+	blinkReverse  bool
 }
 
 // NewWindow creates a window of size h(eight) and w(idth) at y, x
 func NewWindow(h, w, y, x int) (window *Window, err error) {
-	window = &Window{C.newwin(C.int(h), C.int(w), C.int(y), C.int(x))}
+	window = &Window{win: C.newwin(C.int(h), C.int(w), C.int(y), C.int(x))}
 	if window.win == nil {
-		err = errors.New("Failed to create a new window")
+		err = cursesError("newwin")
 	}
 	return
 }
@@ -34,26 +45,56 @@ func (w *Window) AddChar(ach Char) {
 	C.waddch(w.win, C.chtype(ach))
 }
 
+// AddChars writes a run of characters, each of which may be OR'd together
+// with attributes and colors, starting at the cursor. This is much faster
+// than calling AddChar in a loop. Unlike AddChar, the cursor is not
+// advanced and the characters are truncated at the right edge of the window
+// rather than wrapped.
+func (w *Window) AddChars(chars []Char) error {
+	if len(chars) == 0 {
+		return nil
+	}
+	if C.waddchnstr(w.win, (*C.chtype)(unsafe.Pointer(&chars[0])),
+		C.int(len(chars))) == C.ERR {
+		return cursesError("waddchnstr")
+	}
+	return nil
+}
+
 // MoveAddChar prints a single character to the window at the specified
 // y x coordinates. See AddChar for more info.
 func (w *Window) MoveAddChar(y, x int, ach Char) {
 	C.mvwaddch(w.win, C.int(y), C.int(x), C.chtype(ach))
 }
 
-// Turn off character attribute.
-func (w *Window) AttrOff(attr Char) (err error) {
+// AttrGet returns the attributes and color pair currently in effect for the
+// window. The attributes do not include the color pair bits; the pair number
+// is returned separately.
+func (w *Window) AttrGet() (attr Char, pair int16, err error) {
+	var a C.attr_t
+	var p C.short
+	if C.ncurses_wattr_get(w.win, &a, &p) == C.ERR {
+		return 0, 0, cursesError("wattr_get")
+	}
+	return Char(a) &^ A_COLOR, int16(p), nil
+}
+
+// Turn off character attribute. Multiple attributes may be supplied, in
+// which case they are OR'd together and turned off in a single call.
+func (w *Window) AttrOff(attrs ...Char) (err error) {
+	attr := combineAttrs(attrs)
 	if C.ncurses_wattroff(w.win, C.int(attr)) == C.ERR {
-		err = errors.New(fmt.Sprintf("Failed to unset attribute: %s",
-			attrList[C.int(attr)]))
+		err = cursesError("wattroff")
 	}
 	return
 }
 
-// Turn on character attribute
-func (w *Window) AttrOn(attr Char) (err error) {
+// Turn on character attribute. Multiple attributes may be supplied, in
+// which case they are OR'd together and turned on in a single call.
+func (w *Window) AttrOn(attrs ...Char) (err error) {
+	attr := combineAttrs(attrs)
 	if C.ncurses_wattron(w.win, C.int(attr)) == C.ERR {
-		err = errors.New(fmt.Sprintf("Failed to set attribute: %s",
-			attrList[C.int(attr)]))
+		err = cursesError("wattron")
 	}
 	return
 }
@@ -61,18 +102,51 @@ func (w *Window) AttrOn(attr Char) (err error) {
 // AttrSet sets the attributes to the given value
 func (w *Window) AttrSet(attr Char) error {
 	if C.ncurses_wattrset(w.win, C.int(attr)) == C.ERR {
-		return errors.New("Failed to set attributes")
+		return cursesError("wattrset")
 	}
 	return nil
 }
 
+// Attributes returns the names of the attributes currently turned on in the
+// window, such as "bold", sorted alphabetically. The color pair is not
+// included; use AttrGet to retrieve it.
+func (w *Window) Attributes() []string {
+	attr, _, err := w.AttrGet()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for a, name := range attrList {
+		if a != C.A_NORMAL && a != C.A_CHARTEXT && attr&Char(a) == Char(a) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // SetBackground fills the background with the supplied attributes and/or
-// characters.
+// characters. A character, attributes and a color pair may all be set in
+// one call by OR'ing them together, for example:
+//
+//	w.SetBackground(' ' | A_BOLD | ColorPair(1))
 func (w *Window) SetBackground(attr Char) {
 	C.wbkgd(w.win, C.chtype(attr))
 }
 
-// Background returns the current background attributes
+// SetBackgroundChar sets the background character and attributes used for
+// blanks written to the window from now on, such as those produced by
+// Erase() or ClearToEOL(), without changing any of the existing contents.
+// This differs from SetBackground, which also applies the new background to
+// every cell already in the window.
+func (w *Window) SetBackgroundChar(ch Char) {
+	C.wbkgdset(w.win, C.chtype(ch))
+}
+
+// Background returns the current background character along with its
+// attributes and color pair. The value returned can later be passed to
+// SetBackground to restore the window's background after temporarily
+// changing it.
 func (w *Window) Background() Char {
 	return Char(C.ncurses_getbkgd(w.win))
 }
@@ -84,16 +158,42 @@ func (w *Window) Border(ls, rs, ts, bs, tl, tr, bl, br Char) error {
 		C.chtype(bs), C.chtype(tl), C.chtype(tr), C.chtype(bl),
 		C.chtype(br))
 	if res == C.ERR {
-		return errors.New("Failed to draw box around window")
+		return cursesError("wborder")
 	}
 	return nil
 }
 
+// BorderDefault draws a border around the window using the default ACS
+// line drawing characters for each side and corner. It is equivalent to
+// passing zero (0) for every argument to Border().
+func (w *Window) BorderDefault() error {
+	return w.Border(0, 0, 0, 0, 0, 0, 0, 0)
+}
+
 // Box draws a border around the given window. For complete control over the
-// characters used to draw the border use Border()
+// characters used to draw the border use Border(). A value of zero (0) for
+// either vch or hch uses the default ACS_VLINE or ACS_HLINE character
+// respectively.
 func (w *Window) Box(vch, hch Char) error {
+	if vch == 0 {
+		vch = ACS(ACS_VLINE)
+	}
+	if hch == 0 {
+		hch = ACS(ACS_HLINE)
+	}
 	if C.box(w.win, C.chtype(vch), C.chtype(hch)) == C.ERR {
-		return errors.New("Failed to draw box around window")
+		return cursesError("box")
+	}
+	return nil
+}
+
+// ChangeAt changes the attributes and color pair of n characters starting
+// at the coordinates y, x without altering the characters themselves. A
+// value of -1 for n changes the attributes to the end of the line.
+func (w *Window) ChangeAt(y, x, n int, pair int16, attrs ...Char) error {
+	if C.mvwchgat(w.win, C.int(y), C.int(x), C.int(n),
+		C.attr_t(combineAttrs(attrs)), C.short(pair), nil) == C.ERR {
+		return cursesError("mvwchgat")
 	}
 	return nil
 }
@@ -106,14 +206,16 @@ func (w *Window) Box(vch, hch Char) error {
 // by a call to ClearOk().
 func (w *Window) Clear() error {
 	if C.wclear(w.win) == C.ERR {
-		return errors.New("Failed to clear screen")
+		return cursesError("wclear")
 	}
 	return nil
 }
 
 // ClearOk clears the window completely prior to redrawing it. If called
-// on stdscr then the whole screen is redrawn no matter which window has
-// Refresh() called on it. Defaults to False.
+// on stdscr then the whole screen is cleared and redrawn from scratch on
+// the next call to Refresh() no matter which window has Refresh() called
+// on it. This is useful for recovering from a screen which has been
+// corrupted by output from another program. Defaults to False.
 func (w *Window) ClearOk(ok bool) {
 	C.clearok(w.win, C.bool(ok))
 }
@@ -122,7 +224,7 @@ func (w *Window) ClearOk(ok bool) {
 // bottom of window
 func (w *Window) ClearToBottom() error {
 	if C.wclrtobot(w.win) == C.ERR {
-		return errors.New("Failed to clear bottom of window")
+		return cursesError("wclrtobot")
 	}
 	return nil
 }
@@ -131,20 +233,42 @@ func (w *Window) ClearToBottom() error {
 // of the line
 func (w *Window) ClearToEOL() error {
 	if C.wclrtoeol(w.win) == C.ERR {
-		return errors.New("Failed to clear to end of line")
+		return cursesError("wclrtoeol")
 	}
 	return nil
 }
 
-// Color sets the forground/background color pair for the entire window
+// Clone behaves like Duplicate, creating an exact copy of the window at the
+// same position, but returns an error if the window is a sub-window. A
+// sub-window shares its characters with its parent, which a copy can not,
+// so duplicating one silently produces a standalone window instead.
+func (w *Window) Clone() (*Window, error) {
+	if C.ncurses_is_subwin(w.win) {
+		return nil, errors.New("Can not clone a sub-window")
+	}
+	return w.Duplicate()
+}
+
+// Close deletes the window like Delete but does nothing if the window has
+// already been deleted, so that it is safe to defer even on paths where the
+// window may have been deleted explicitly.
+func (w *Window) Close() error {
+	if w.win == nil {
+		return nil
+	}
+	return w.Delete()
+}
+
+// Color sets the forground/background color pair used for characters
+// subsequently written to the window
 func (w *Window) Color(pair int16) {
-	C.wcolor_set(w.win, C.short(ColorPair(pair)), nil)
+	C.wcolor_set(w.win, C.short(pair), nil)
 }
 
 // ColorOff turns the specified color pair off
 func (w *Window) ColorOff(pair int16) error {
 	if C.ncurses_wattroff(w.win, C.int(ColorPair(pair))) == C.ERR {
-		return errors.New("Failed to enable color pair")
+		return cursesError("wattroff")
 	}
 	return nil
 }
@@ -153,13 +277,17 @@ func (w *Window) ColorOff(pair int16) error {
 // implementation chose to make it seperate
 func (w *Window) ColorOn(pair int16) error {
 	if C.ncurses_wattron(w.win, C.int(ColorPair(pair))) == C.ERR {
-		return errors.New("Failed to enable color pair")
+		return cursesError("wattron")
 	}
 	return nil
 }
 
 // Copy is similar to Overlay and Overwrite but provides a finer grain of
-// control.
+// control. The rectangle of src beginning at sy, sx is copied onto the
+// area of this window bounded by the upper-left corner dtr, dtc and the
+// lower-right corner dbr, dbc. If overlay is true then blank characters in
+// src are not copied, as with Overlay; otherwise the copy is destructive,
+// as with Overwrite.
 func (w *Window) Copy(src *Window, sy, sx, dtr, dtc, dbr, dbc int,
 	overlay bool) error {
 	var ol int
@@ -169,7 +297,7 @@ func (w *Window) Copy(src *Window, sy, sx, dtr, dtc, dbr, dbc int,
 	if C.copywin(src.win, w.win, C.int(sy), C.int(sx),
 		C.int(dtr), C.int(dtc), C.int(dbr), C.int(dbc), C.int(ol)) ==
 		C.ERR {
-		return errors.New("Failed to copy window")
+		return cursesError("copywin")
 	}
 	return nil
 }
@@ -179,8 +307,7 @@ func (w *Window) Copy(src *Window, sy, sx, dtr, dtc, dbr, dbc int,
 // a blank character at the end.
 func (w *Window) DelChar() error {
 	if err := C.wdelch(w.win); err != C.OK {
-		return errors.New("An error occurred when trying to delete " +
-			"character")
+		return cursesError("wdelch")
 	}
 	return nil
 }
@@ -190,37 +317,97 @@ func (w *Window) DelChar() error {
 // a blank character at the end.
 func (w *Window) MoveDelChar(y, x int) error {
 	if err := C.mvwdelch(w.win, C.int(y), C.int(x)); err != C.OK {
-		return errors.New("An error occurred when trying to delete " +
-			"character")
+		return cursesError("mvwdelch")
 	}
 	return nil
 }
 
 // Delete the window. This function must be called to ensure memory is freed
-// to prevent memory leaks once you are done with the window.
+// to prevent memory leaks once you are done with the window. The window
+// must not be used after it has been deleted; doing so will cause most
+// functions to fail and calling Delete again will return an error. Note that
+// copies of the Window, such as those returned by StdScr or Parent, are not
+// affected and must not be used either.
 func (w *Window) Delete() error {
+	if w.win == nil {
+		return errors.New("Window already deleted")
+	}
 	if C.delwin(w.win) == C.ERR {
-		return errors.New("Failed to delete window")
+		return cursesError("delwin")
 	}
-	w = nil
+	*w = Window{}
 	return nil
 }
 
 // Derived creates a new window of height and width at the coordinates
 // y, x.  These coordinates are relative to the original window thereby
 // confining the derived window to the area of original window. See the
-// SubWindow function for additional notes.
+// Sub function for additional notes.
 func (w *Window) Derived(height, width, y, x int) *Window {
-	return &Window{C.derwin(w.win, C.int(height), C.int(width), C.int(y),
+	return &Window{win: C.derwin(w.win, C.int(height), C.int(width), C.int(y),
 		C.int(x))}
 }
 
-// Duplicate the window, creating an exact copy.
-func (w *Window) Duplicate() *Window {
-	return &Window{C.dupwin(w.win)}
+// DrawBox draws a border around the window using the default line drawing
+// characters and writes title into the top border. The title is placed
+// according to align, one of ALIGN_LEFT, ALIGN_CENTER or ALIGN_RIGHT, and is
+// clipped to fit between the corners. Each rune of the title is assumed to
+// occupy a single column, so titles containing double-width runes may be
+// clipped too late and overwrite the corner. The cursor position is left
+// unchanged.
+func (w *Window) DrawBox(title string, align int) error {
+	if align < ALIGN_LEFT || align > ALIGN_RIGHT {
+		return errors.New("Invalid title alignment")
+	}
+	if err := w.BorderDefault(); err != nil {
+		return err
+	}
+	_, mx := w.MaxYX()
+	inner := mx - 2
+	if inner < 0 {
+		inner = 0
+	}
+	r := []rune(title)
+	if len(r) > inner {
+		r = r[:inner]
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	x := 1
+	switch align {
+	case ALIGN_CENTER:
+		x += (inner - len(r)) / 2
+	case ALIGN_RIGHT:
+		x += inner - len(r)
+	}
+	cy, cx := w.CursorYX()
+	defer w.Move(cy, cx)
+	return w.moveAddString(0, x, string(r))
+}
+
+// Duplicate the window, creating an exact copy. If the window is a
+// sub-window the copy is a new, independent window which no longer shares
+// memory with the parent; use Clone to guard against this.
+func (w *Window) Duplicate() (*Window, error) {
+	dup := C.dupwin(w.win)
+	if dup == nil {
+		return nil, cursesError("dupwin")
+	}
+	return &Window{win: dup}, nil
+}
+
+// EchoChar behaves like AddChar followed by Refresh but is optimised for
+// output of a single character, such as when echoing input as it is typed.
+func (w *Window) EchoChar(ch Char) error {
+	if C.wechochar(w.win, C.chtype(ch)) == C.ERR {
+		return cursesError("wechochar")
+	}
+	return nil
 }
 
-// Test whether the given coordinates are within the window or not
+// Test whether the given screen-relative coordinates, such as those
+// reported by a MouseEvent, are within the window or not
 func (w *Window) Enclose(y, x int) bool {
 	return bool(C.wenclose(w.win, C.int(y), C.int(x)))
 }
@@ -233,10 +420,60 @@ func (w *Window) Erase() {
 	C.werase(w.win)
 }
 
+// Fill paints the rectangle of height by width characters at y, x with ch,
+// OR'd together with any attributes supplied. The rectangle is clipped to
+// the bounds of the window and the cursor is left where it was.
+func (w *Window) Fill(y, x, height, width int, ch Char, attrs ...Char) error {
+	my, mx := w.MaxYX()
+	if y < 0 {
+		height += y
+		y = 0
+	}
+	if x < 0 {
+		width += x
+		x = 0
+	}
+	if y+height > my {
+		height = my - y
+	}
+	if x+width > mx {
+		width = mx - x
+	}
+	if height <= 0 || width <= 0 {
+		return nil
+	}
+	ch |= combineAttrs(attrs)
+	cy, cx := w.CursorYX()
+	defer w.Move(cy, cx)
+	for row := y; row < y+height; row++ {
+		if C.mvwhline(w.win, C.int(row), C.int(x), C.chtype(ch),
+			C.int(width)) == C.ERR {
+			return cursesError("mvwhline")
+		}
+	}
+	return nil
+}
+
+// ForceRepaint clears and redraws the whole screen from scratch while
+// refreshing the window. Unlike Refresh, which only outputs the changes
+// curses believes are needed, curses discards what it believes is on the
+// screen. Use it to recover after something other than curses, such as a
+// library logging to stdout or stderr, has written to the terminal. Redraw
+// should be preferred when only this window has been corrupted.
+func (w *Window) ForceRepaint() error {
+	C.clearok(w.win, true)
+	w.blink()
+	if C.wrefresh(w.win) == C.ERR {
+		return cursesError("wrefresh")
+	}
+	return nil
+}
+
 // GetChar retrieves a character from standard input stream and returns it.
 // In the event of an error or if the input timeout has expired (ie. if
 // Timeout() has been set to zero or a positive value and no characters have
-// been received) the value returned will be zero (0)
+// been received) the value returned will be zero (0). Use ReadChar to tell
+// the two cases apart.
 func (w *Window) GetChar() Key {
 	ch := C.wgetch(w.win)
 	if ch == C.ERR {
@@ -245,6 +482,119 @@ func (w *Window) GetChar() Key {
 	return Key(ch)
 }
 
+// contextPollDelay is the time, in milliseconds, GetCharContext waits for
+// input before checking whether its context is done
+const contextPollDelay = 50
+
+// GetCharContext behaves like ReadChar but returns ctx.Err() if the context
+// is cancelled or times out before a character is received. The window's
+// delay is temporarily replaced with a short timeout which is used to check
+// the context, so cancellation may take up to 50 milliseconds to be seen.
+// An error is returned if the input stream fails or reaches end of file.
+func (w *Window) GetCharContext(ctx context.Context) (Key, error) {
+	delay := C.ncurses_wgetdelay(w.win)
+	C.wtimeout(w.win, contextPollDelay)
+	defer C.wtimeout(w.win, delay)
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		if ch := C.wgetch(w.win); ch != C.ERR {
+			return Key(ch), nil
+		}
+		if err := w.inputError("wgetch", start); err != ErrInputTimeout {
+			return 0, err
+		}
+	}
+}
+
+// ReadChar behaves like GetChar but returns an error when no character could
+// be retrieved. If the window is in a delay mode, set via Timeout or
+// HalfDelay, and the delay expires before any input is received then
+// ErrInputTimeout is returned so that it can be told apart from a failure
+// of the input stream, such as reaching end of file. With a delay of zero
+// the two can not be told apart and ErrInputTimeout is always returned.
+func (w *Window) ReadChar() (Key, error) {
+	start := time.Now()
+	ch := C.wgetch(w.win)
+	if ch == C.ERR {
+		return 0, w.inputError("wgetch", start)
+	}
+	return Key(ch), nil
+}
+
+// inputError returns the error for a read, begun at start by the curses
+// function fn, which returned ERR. A read which gave up well before the
+// window's delay expired failed rather than timed out, as wgetch returns at
+// once when the input is at end of file or can not be read.
+func (w *Window) inputError(fn string, start time.Time) error {
+	delay := time.Duration(C.ncurses_wgetdelay(w.win)) * time.Millisecond
+	if halfDelay > 0 {
+		delay = time.Duration(halfDelay) * 100 * time.Millisecond
+	}
+	if delay < 0 || time.Since(start) < delay/2 {
+		return cursesError(fn)
+	}
+	return ErrInputTimeout
+}
+
+// GetKey reads a character from the input stream and returns its name as
+// given by KeyString, such as "down", "enter" or the character itself. Use
+// GetChar or ReadChar when the raw value is required, for example to detect
+// KEY_MOUSE events.
+func (w *Window) GetKey() (string, error) {
+	k, err := w.ReadChar()
+	if err != nil {
+		return "", err
+	}
+	return KeyString(k), nil
+}
+
+// rawSequenceDelay is the time, in milliseconds, GetRawBytes waits for each
+// subsequent byte of an input sequence
+const rawSequenceDelay = 25
+
+// GetRawBytes reads a single key press and returns the bytes sent by the
+// terminal, such as the full escape sequence of a function key, rather than
+// a decoded KEY_* value. Keypad decoding is turned off for the duration of
+// the call, so it can't be combined with Keypad. The window's delay, as set
+// by Timeout, applies to the first byte; the rest of the sequence is
+// collected until no further byte arrives within a short delay.
+func (w *Window) GetRawBytes() ([]byte, error) {
+	keypad := C.ncurses_is_keypad(w.win)
+	delay := C.ncurses_wgetdelay(w.win)
+	C.keypad(w.win, false)
+	defer func() {
+		C.keypad(w.win, keypad)
+		C.wtimeout(w.win, delay)
+	}()
+
+	ch, err := w.ReadChar()
+	if err != nil {
+		return nil, err
+	}
+	buf := []byte{byte(ch)}
+	C.wtimeout(w.win, rawSequenceDelay)
+	for {
+		ch := C.wgetch(w.win)
+		if ch == C.ERR {
+			break
+		}
+		buf = append(buf, byte(ch))
+	}
+	return buf, nil
+}
+
+// GetKeyEvent behaves like ReadChar but also reports whether the key is a
+// function key, such as KEY_DOWN or KEY_F1, which is the case when its
+// value lies between KEY_MIN and KEY_MAX. Function keys are only reported
+// when Keypad is enabled. See GetWChar for reading multi-byte characters.
+func (w *Window) GetKeyEvent() (ch Key, isFunctionKey bool, err error) {
+	ch, err = w.ReadChar()
+	return ch, ch >= KEY_MIN && ch <= KEY_MAX, err
+}
+
 // MoveGetChar moves the cursor to the given position and gets a character
 // from the input stream
 func (w *Window) MoveGetChar(y, x int) Key {
@@ -254,28 +604,131 @@ func (w *Window) MoveGetChar(y, x int) Key {
 // GetString reads at most 'n' characters entered by the user from the Window.
 // Attempts to enter greater than 'n' characters will elicit a 'beep'
 func (w *Window) GetString(n int) (string, error) {
-	cstr := make([]C.char, n)
-	if C.wgetnstr(w.win, (*C.char)(&cstr[0]), C.int(n)) == C.ERR {
-		return "", errors.New("Failed to retrieve string from input stream")
+	return w.GetStringInto(make([]byte, n+1))
+}
+
+// GetStringInto behaves like GetString but reads into the supplied buffer
+// rather than allocating a new one, which is useful when repeatedly reading
+// input. At most len(buf)-1 characters are read to leave room for the
+// terminating null character.
+func (w *Window) GetStringInto(buf []byte) (string, error) {
+	if len(buf) < 2 {
+		return "", errors.New("Buffer too small to retrieve string")
+	}
+	if C.wgetnstr(w.win, (*C.char)(unsafe.Pointer(&buf[0])),
+		C.int(len(buf)-1)) == C.ERR {
+		return "", cursesError("wgetnstr")
+	}
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+	return string(buf), nil
+}
+
+// GetPassword behaves like GetString but with echo turned off, so that the
+// characters typed are not displayed. Echo is restored to its previous
+// state afterward, even if reading fails.
+func (w *Window) GetPassword(n int) (string, error) {
+	prev := echo
+	Echo(false)
+	defer Echo(prev)
+	return w.GetString(n)
+}
+
+// GetMasked reads at most n characters entered by the user, drawing mask in
+// place of each one, such as '*' for password entry. Backspace erases the
+// last character and Enter completes the input. Input is also limited by
+// the right edge of the window; attempts to enter more characters than fit
+// elicit a beep. Echo is turned off while reading and then restored.
+func (w *Window) GetMasked(n int, mask rune) (string, error) {
+	prev := echo
+	Echo(false)
+	defer Echo(prev)
+
+	_, mx := w.MaxYX()
+	var input []rune
+	var pending []byte
+	for {
+		k, err := w.ReadChar()
+		if err != nil {
+			return string(input), err
+		}
+		switch k {
+		case '\n', '\r', KEY_ENTER:
+			return string(input), nil
+		case KEY_BACKSPACE, 0x7f, '\b':
+			if len(input) == 0 {
+				Beep()
+				continue
+			}
+			input = input[:len(input)-1]
+			y, x := w.CursorYX()
+			w.MoveAddChar(y, x-1, ' ')
+			w.Move(y, x-1)
+			continue
+		}
+		if k < ' ' || k > 0xff {
+			continue
+		}
+		pending = append(pending, byte(k))
+		if !utf8.FullRune(pending) {
+			continue
+		}
+		r, _ := utf8.DecodeRune(pending)
+		pending = pending[:0]
+		if _, x := w.CursorYX(); len(input) >= n || x >= mx-1 {
+			Beep()
+			continue
+		}
+		input = append(input, r)
+		w.addString(string(mask))
 	}
-	return C.GoString(&cstr[0]), nil
 }
 
-// Getyx returns the current cursor location in the Window. Note that it uses
-// ncurses idiom of returning y then x.
+// CursorYX returns the current cursor location in the Window. Note that it
+// uses ncurses idiom of returning y then x.
 func (w *Window) CursorYX() (int, int) {
 	var cy, cx C.int
 	C.ncurses_getyx(w.win, &cy, &cx)
 	return int(cy), int(cx)
 }
 
+// CurY returns the row of the cursor. See CursorYX
+func (w *Window) CurY() int {
+	y, _ := w.CursorYX()
+	return y
+}
+
+// CurX returns the column of the cursor. See CursorYX
+func (w *Window) CurX() int {
+	_, x := w.CursorYX()
+	return x
+}
+
 // HLine draws a horizontal line starting at y, x and ending at width using
-// the specified character
+// the specified character. Attributes and a color pair may be OR'd into ch,
+// such as ACS_HLINE|A_BOLD, to draw a styled line.
 func (w *Window) HLine(y, x int, ch Char, wid int) {
 	C.mvwhline(w.win, C.int(y), C.int(x), C.chtype(ch), C.int(wid))
 	return
 }
 
+// IdlOk specifies whether ncurses may use the terminal's hardware insert
+// and delete line capabilities. This can speed up scrolling but may cause
+// the screen to visibly jump on some terminals, which is why it defaults to
+// False. It is usually only worth enabling for windows which scroll.
+func (w *Window) IdlOk(ok bool) {
+	C.idlok(w.win, C.bool(ok))
+}
+
+// ImmedOk specifies whether the window is automatically refreshed after
+// every change made to it, as though Refresh() had been called. This can
+// significantly degrade performance since every character written to the
+// window results in output to the terminal. Defaults to False.
+func (w *Window) ImmedOk(ok bool) {
+	C.immedok(w.win, C.bool(ok))
+}
+
 // InChar returns the character at the current position in the curses window
 func (w *Window) InChar() Char {
 	return Char(C.winch(w.win))
@@ -287,7 +740,96 @@ func (w *Window) MoveInChar(y, x int) Char {
 	return Char(C.mvwinch(w.win, C.int(y), C.int(x)))
 }
 
-// IsCleared returns the value set in ClearOk
+// InChars returns at most n characters read from the window, starting at
+// the current cursor position and stopping at the right edge of the window.
+// Unlike InString, each character retains its attributes and color pair.
+// The cursor is not moved.
+func (w *Window) InChars(n int) []Char {
+	if n <= 0 {
+		return nil
+	}
+	chars := make([]Char, n+1)
+	count := C.winchnstr(w.win, (*C.chtype)(unsafe.Pointer(&chars[0])),
+		C.int(n))
+	if count == C.ERR {
+		return nil
+	}
+	return chars[:count]
+}
+
+// InString returns a string of at most n characters read from the window,
+// starting at the current cursor position. Attributes are stripped from
+// the characters returned.
+func (w *Window) InString(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	cstr := make([]C.char, n+1)
+	if C.winnstr(w.win, &cstr[0], C.int(n)) == C.ERR {
+		return ""
+	}
+	return C.GoString(&cstr[0])
+}
+
+// MoveInString moves the cursor to the designated coordinates and returns
+// a string of at most n characters read from the window. See InString for
+// more details.
+func (w *Window) MoveInString(y, x, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	cstr := make([]C.char, n+1)
+	if C.mvwinnstr(w.win, C.int(y), C.int(x), &cstr[0], C.int(n)) == C.ERR {
+		return ""
+	}
+	return C.GoString(&cstr[0])
+}
+
+// Intersection returns the area of the screen covered by both the window
+// and other as the screen coordinates of its upper-left corner and its size.
+// If the windows do not overlap, ok is false.
+func (w *Window) Intersection(other *Window) (y, x, height, width int,
+	ok bool) {
+	y1, x1 := w.YX()
+	h1, w1 := w.MaxYX()
+	y2, x2 := other.YX()
+	h2, w2 := other.MaxYX()
+
+	y, x = y1, x1
+	if y2 > y {
+		y = y2
+	}
+	if x2 > x {
+		x = x2
+	}
+	bottom, right := y1+h1, x1+w1
+	if y2+h2 < bottom {
+		bottom = y2 + h2
+	}
+	if x2+w2 < right {
+		right = x2 + w2
+	}
+	height, width = bottom-y, right-x
+	if height <= 0 || width <= 0 {
+		return 0, 0, 0, 0, false
+	}
+	return y, x, height, width, true
+}
+
+// IntrFlush specifies whether pressing an interrupt, quit or suspend key
+// flushes all output in the terminal driver. Flushing gives a faster
+// response to the interrupt but leaves curses with the wrong idea of what
+// is on the screen. Although it is set on a window, the option affects the
+// whole terminal.
+func (w *Window) IntrFlush(on bool) error {
+	if C.intrflush(w.win, C.bool(on)) == C.ERR {
+		return cursesError("intrflush")
+	}
+	return nil
+}
+
+// IsCleared returns the value set in ClearOk. The value is reset once the
+// window has been refreshed.
 func (w *Window) IsCleared() bool {
 	return bool(C.ncurses_is_cleared(w.win))
 }
@@ -298,39 +840,96 @@ func (w *Window) IsKeypad() bool {
 }
 
 // Keypad turns on/off the keypad characters, including those like the F1-F12
-// keys and the arrow keys
+// keys and the arrow keys. See NoTimeout for how escape sequences are timed.
 func (w *Window) Keypad(keypad bool) error {
 	var err C.int
 	if err = C.keypad(w.win, C.bool(keypad)); err == C.ERR {
-		return errors.New("Unable to set keypad mode")
+		return cursesError("keypad")
 	}
 	return nil
 }
 
-// LineTouched returns true if the line has been touched; returns false
-// otherwise
+// LineTouched returns true if the line has been touched, meaning it has
+// changed since the window was last refreshed or has been marked with Touch
+// or TouchLine; returns false otherwise. Together with Touched it can be
+// used to find which parts of a window need to be redrawn.
 func (w *Window) LineTouched(line int) bool {
 	return bool(C.is_linetouched(w.win, C.int(line)))
 }
 
-// Returns the maximum size of the Window. Note that it uses ncurses idiom
-// of returning y then x.
+// MaxYX returns the maximum size of the Window. Note that it uses ncurses
+// idiom of returning y then x.
 func (w *Window) MaxYX() (int, int) {
 	var cy, cx C.int
 	C.ncurses_getmaxyx(w.win, &cy, &cx)
 	return int(cy), int(cx)
 }
 
+// MaxY returns the number of rows in the Window. See MaxYX
+func (w *Window) MaxY() int {
+	y, _ := w.MaxYX()
+	return y
+}
+
+// MaxX returns the number of columns in the Window. See MaxYX
+func (w *Window) MaxX() int {
+	_, x := w.MaxYX()
+	return x
+}
+
+// Meta specifies whether input is 8 or 7 bits. With meta on, the terminal
+// sends the high bit of each character, which is how some terminals report
+// a key pressed with Alt held down. Although it is set on a window, the
+// option affects the whole terminal.
+func (w *Window) Meta(on bool) error {
+	if C.meta(w.win, C.bool(on)) == C.ERR {
+		return cursesError("meta")
+	}
+	return nil
+}
+
 // Move the cursor to the specified coordinates within the window
 func (w *Window) Move(y, x int) {
 	C.wmove(w.win, C.int(y), C.int(x))
 	return
 }
 
-// MoveWindow moves the location of the window to the specified coordinates
-func (w *Window) MoveWindow(y, x int) {
-	C.mvwin(w.win, C.int(y), C.int(x))
-	return
+// MoveWindow moves the location of the window to the specified coordinates.
+// An error is returned if the move would place any part of the window
+// off-screen, in which case the window is not moved.
+func (w *Window) MoveWindow(y, x int) error {
+	if C.mvwin(w.win, C.int(y), C.int(x)) == C.ERR {
+		return cursesError("mvwin")
+	}
+	return nil
+}
+
+// MouseTrafo transforms the coordinates y, x between screen-relative and
+// window-relative coordinates. If toScreen is true, window-relative
+// coordinates are converted to screen-relative ones, otherwise the
+// conversion is from screen to window. The final return value is false,
+// and the coordinates are returned unchanged, if the location is not
+// within the window.
+func (w *Window) MouseTrafo(y, x int, toScreen bool) (int, int, bool) {
+	cy, cx := C.int(y), C.int(x)
+	if !C.wmouse_trafo(w.win, &cy, &cx, C.bool(toScreen)) {
+		return y, x, false
+	}
+	return int(cy), int(cx), true
+}
+
+// NoTimeout specifies whether GetChar waits indefinitely for the rest of
+// an escape sequence when Keypad is enabled. Normally, curses starts a
+// timer after receiving an escape and, if the rest of a function key's
+// sequence does not arrive in time, returns the escape as a separate key.
+// On slow connections the timer may expire mid-sequence; turning the timer
+// off avoids this at the cost of a lone escape key press blocking until
+// more input arrives.
+func (w *Window) NoTimeout(on bool) error {
+	if C.notimeout(w.win, C.bool(on)) == C.ERR {
+		return cursesError("notimeout")
+	}
+	return nil
 }
 
 // NoutRefresh, or No Output Refresh, flags the window for redrawing but does
@@ -338,17 +937,33 @@ func (w *Window) MoveWindow(y, x int) {
 // buffered and a call to Update() flushes the buffer to the terminal. This
 // function provides a speed increase over calling Refresh() when multiple
 // windows are involved because only the final output is
-// transmitted to the terminal.
+// transmitted to the terminal. The usual idiom is to call NoutRefresh() on
+// each window which has changed followed by a single call to Update():
+//
+//	left.NoutRefresh()
+//	right.NoutRefresh()
+//	goncurses.Update()
+//
+// This also reduces flicker compared to calling Refresh() on each window.
 func (w *Window) NoutRefresh() {
+	w.blink()
 	C.wnoutrefresh(w.win)
 	return
 }
 
+// Overlaps returns true if any part of the window and other cover the same
+// area of the screen. See Intersection
+func (w *Window) Overlaps(other *Window) bool {
+	_, _, _, _, ok := w.Intersection(other)
+	return ok
+}
+
 // Overlay copies overlapping sections of src window onto the destination
-// window. Non-blank elements are not overwritten.
+// window. Blank characters in src do not overwrite the contents of the
+// destination window.
 func (w *Window) Overlay(src *Window) error {
 	if C.overlay(src.win, w.win) == C.ERR {
-		return errors.New("Failed to overlay window")
+		return cursesError("overlay")
 	}
 	return nil
 }
@@ -358,120 +973,351 @@ func (w *Window) Overlay(src *Window) error {
 // elements of src onto the destination window.
 func (w *Window) Overwrite(src *Window) error {
 	if C.overwrite(src.win, w.win) == C.ERR {
-		return errors.New("Failed to overwrite window")
+		return cursesError("overwrite")
 	}
 	return nil
 }
 
+// ParYX returns the coordinates of a sub-window relative to its parent
+// window. If the window is not a sub-window then -1, -1 is returned. Note
+// that it uses ncurses idiom of returning y then x.
+func (w *Window) ParYX() (int, int) {
+	var y, x C.int
+	C.ncurses_getparyx(w.win, &y, &x)
+	return int(y), int(x)
+}
+
 // Parent returns a pointer to a Sub-window's parent, or nil if the window
-// has no parent
+// has no parent. Parent can be called repeatedly to walk up a hierarchy of
+// nested sub-windows to the top-level window. The returned Window refers to
+// the same curses window as the parent, so it must not be used once the
+// parent has been deleted.
 func (w *Window) Parent() *Window {
 	p := C.ncurses_wgetparent(w.win)
 	if p == nil {
 		return nil
 	}
-	return &Window{p}
+	return &Window{win: p}
 }
 
 // Print a string to the given window. See the fmt package in the standard
-// library for more information. In order to simulate the 'n' version
-// of functions (like addnstr) just slice your string to the maximum
-// length before passing it as an argument.
+// library for more information. The arguments are formatted as by
+// fmt.Sprint, so a literal '%' in a string is printed as-is. Print is
+// therefore safe to use for untrusted text, such as file names, whereas
+// Printf should only be given a constant format. In order to simulate the
+// 'n' version of functions (like addnstr) just slice your string to the
+// maximum length before passing it as an argument.
 // window.Print("My line which should be clamped to 20 characters"[:20])
 func (w *Window) Print(args ...interface{}) {
-	w.Printf("%s", fmt.Sprint(args...))
+	w.addString(fmt.Sprint(args...))
 }
 
 // Printf functions the same as the stardard library's fmt package. See Print
 // for more details.
 func (w *Window) Printf(format string, args ...interface{}) {
-	cstr := C.CString(fmt.Sprintf(format, args...))
-	defer C.free(unsafe.Pointer(cstr))
-
-	C.waddstr(w.win, cstr)
+	w.addString(fmt.Sprintf(format, args...))
 }
 
-// Println behaves the s as Println in the stanard library's fmt package.
+// Println behaves the same as Println in the standard library's fmt package.
+// The trailing newline moves the cursor to the start of the next line. If
+// the cursor is on the last line of the scrolling region and ScrollOk is
+// enabled the window is scrolled up, otherwise the cursor stays put.
 // See Print for more information.
 func (w *Window) Println(args ...interface{}) {
-	w.Printf("%s", fmt.Sprintln(args...))
+	w.addString(fmt.Sprintln(args...))
 }
 
 // MovePrint moves the cursor to the specified coordinates and prints the
 // supplied message. See Print for more details.The first two arguments are the
 // coordinates to print to.
 func (w *Window) MovePrint(y, x int, args ...interface{}) {
-	w.MovePrintf(y, x, "%s", fmt.Sprint(args...))
+	w.moveAddString(y, x, fmt.Sprint(args...))
 }
 
 // MovePrintf moves the cursor to coordinates and prints the message using
-// the specified format. See Printf and MovePrint for more information.
+// the specified format. See Printf and MovePrint for more information. Use
+// WriteAt if you need to know whether the write succeeded.
 func (w *Window) MovePrintf(y, x int, format string, args ...interface{}) {
-	cstr := C.CString(fmt.Sprintf(format, args...))
-	defer C.free(unsafe.Pointer(cstr))
-
-	C.mvwaddstr(w.win, C.int(y), C.int(x), cstr)
+	w.moveAddString(y, x, fmt.Sprintf(format, args...))
 }
 
 // MovePrintln moves the cursor to coordinates and prints the message. See
 // Println and MovePrint for more details.
 func (w *Window) MovePrintln(y, x int, args ...interface{}) {
-	w.MovePrintf(y, x, "%s", fmt.Sprintln(args...))
+	w.moveAddString(y, x, fmt.Sprintln(args...))
+}
+
+// PrintWrapped writes text starting at y, x, word-wrapped to lines of at
+// most width characters. Lines are broken at spaces where possible and words
+// longer than width are broken wherever they must be. Each newline in text
+// starts a new line. The number of rows written is returned; any lines which
+// would fall below the bottom of the window are discarded. The width is
+// reduced if required so that lines end at the right edge of the window.
+func (w *Window) PrintWrapped(y, x, width int, text string) int {
+	my, mx := w.MaxYX()
+	if x+width > mx {
+		width = mx - x
+	}
+	rows := 0
+	for _, line := range wrapText(text, width) {
+		if y+rows >= my {
+			break
+		}
+		w.moveAddString(y+rows, x, line)
+		rows++
+	}
+	return rows
+}
+
+// wrapText splits text into lines no longer than width runes, breaking
+// between words where possible
+func wrapText(text string, width int) (lines []string) {
+	if width <= 0 {
+		return nil
+	}
+	for _, para := range strings.Split(text, "\n") {
+		var cur []rune
+		for _, word := range strings.Fields(para) {
+			r := []rune(word)
+			if len(cur) > 0 && len(cur)+1+len(r) <= width {
+				cur = append(append(cur, ' '), r...)
+				continue
+			}
+			if len(cur) > 0 {
+				lines = append(lines, string(cur))
+			}
+			for len(r) > width {
+				lines = append(lines, string(r[:width]))
+				r = r[width:]
+			}
+			cur = r
+		}
+		lines = append(lines, string(cur))
+	}
+	return
+}
+
+// Redraw indicates that the entire window has been corrupted and should be
+// completely redrawn on the next call to Refresh
+func (w *Window) Redraw() error {
+	if C.redrawwin(w.win) == C.ERR {
+		return cursesError("redrawwin")
+	}
+	return nil
+}
+
+// RedrawLines behaves like Redraw but only effects num number of lines,
+// beginning at beg
+func (w *Window) RedrawLines(beg, num int) error {
+	if C.wredrawln(w.win, C.int(beg), C.int(num)) == C.ERR {
+		return cursesError("wredrawln")
+	}
+	return nil
 }
 
-// Refresh the window so it's contents will be displayed
+// Refresh the window so it's contents will be displayed. Within a call to
+// Batch, the window is instead marked for output as by NoutRefresh.
 func (w *Window) Refresh() {
+	if batch > 0 {
+		w.NoutRefresh()
+		return
+	}
+	w.blink()
 	C.wrefresh(w.win)
 }
 
-// Resize the window to new height, width
-func (w *Window) Resize(height, width int) {
-	C.wresize(w.win, C.int(height), C.int(width))
+// Resize the window to new height, width. An error is returned, and the
+// window left unchanged, if either dimension is not greater than zero or
+// the window could not be resized.
+func (w *Window) Resize(height, width int) error {
+	if height <= 0 || width <= 0 {
+		return errors.New("Window dimensions must be greater than zero")
+	}
+	if C.wresize(w.win, C.int(height), C.int(width)) == C.ERR {
+		return cursesError("wresize")
+	}
+	return nil
 }
 
-// Scroll the contents of the window. Use a negative number to scroll up,
-// a positive number to scroll down. ScrollOk Must have been called prior.
+// Scroll the contents of the window. A positive number scrolls the lines up,
+// as when text is added at the bottom, and a negative number scrolls them
+// down. ScrollOk Must have been called prior.
 func (w *Window) Scroll(n int) {
 	C.wscrl(w.win, C.int(n))
 }
 
+// ScrollDown scrolls the contents of the window down n lines, leaving blank
+// lines at the top. Unlike Scroll, an error is returned if ScrollOk has not
+// been enabled rather than the window being silently left unchanged.
+func (w *Window) ScrollDown(n int) error {
+	return w.scroll(-n, n)
+}
+
+// ScrollUp scrolls the contents of the window up n lines, leaving blank lines
+// at the bottom. See ScrollDown
+func (w *Window) ScrollUp(n int) error {
+	return w.scroll(n, n)
+}
+
+// scroll checks that n is valid and scrolling is enabled before scrolling
+// the window by lines
+func (w *Window) scroll(lines, n int) error {
+	if n < 0 {
+		return errors.New("Number of lines to scroll must not be negative")
+	}
+	if !w.IsScrollOk() {
+		return errors.New("Scrolling is not enabled; call ScrollOk first")
+	}
+	if C.wscrl(w.win, C.int(lines)) == C.ERR {
+		return cursesError("wscrl")
+	}
+	return nil
+}
+
 // ScrollOk sets whether scrolling will work
 func (w *Window) ScrollOk(ok bool) {
 	C.scrollok(w.win, C.bool(ok))
 }
 
-// SubWindow creates a new window of height and width at the coordinates
-// y, x.  This window shares memory with the original window so changes
-// made to one window are reflected in the other. It is necessary to call
-// Touch() on this window prior to calling Refresh in order for it to be
-// displayed.
+// IsScrollOk returns the value set in ScrollOk
+func (w *Window) IsScrollOk() bool {
+	return bool(C.ncurses_is_scrollok(w.win))
+}
+
+// ScrollRegion returns the top and bottom lines of the scrolling region
+// set by SetScrollRegion. By default the region is the whole window.
+func (w *Window) ScrollRegion() (top, bottom int) {
+	var t, b C.int
+	C.ncurses_wgetscrreg(w.win, &t, &b)
+	return int(t), int(b)
+}
+
+// SetBlinkFallback turns on or off simulated blinking for terminals which
+// are unable to display the A_BLINK attribute. When on, characters in the
+// window with the A_BLINK attribute are alternately shown in reverse video
+// and normally each time the window is refreshed, so Refresh or NoutRefresh
+// should be called periodically for the text to blink. Any A_REVERSE
+// attribute on blinking characters is overridden. If the terminal supports
+// blinking then this function does nothing. The setting belongs to this
+// Window value, so other values referring to the same window, such as those
+// returned by StdScr or Parent, do not blink unless it is set for them too.
+func (w *Window) SetBlinkFallback(on bool) {
+	if Char(C.termattrs())&A_BLINK != 0 {
+		return
+	}
+	if !on && w.blinkFallback {
+		w.setBlinkReverse(false)
+	}
+	w.blinkFallback, w.blinkReverse = on, false
+}
+
+// SetColor sets the foreground and background colors used for characters
+// subsequently written to the window. The colors are given by name, one of
+// black, blue, cyan, green, magenta, red, white or yellow, and a color pair
+// for them is allocated with AllocPair, so there is no need to call InitPair
+// beforehand. ErrBadFGColor or ErrBadBGColor is returned for an unknown name.
+func (w *Window) SetColor(fg, bg string) error {
+	f, ok := colorNames[fg]
+	if !ok {
+		return ErrBadFGColor
+	}
+	b, ok := colorNames[bg]
+	if !ok {
+		return ErrBadBGColor
+	}
+	pair, err := AllocPair(f, b)
+	if err != nil {
+		return err
+	}
+	if C.wcolor_set(w.win, C.short(pair), nil) == C.ERR {
+		return cursesError("wcolor_set")
+	}
+	return nil
+}
+
+// blink alternates the appearance of blinking characters if blinking is
+// being simulated for the window
+func (w *Window) blink() {
+	if w.blinkFallback {
+		w.blinkReverse = !w.blinkReverse
+		w.setBlinkReverse(w.blinkReverse)
+	}
+}
+
+// setBlinkReverse turns reverse video on or off for every character in the
+// window with the A_BLINK attribute, leaving the cursor where it was
+func (w *Window) setBlinkReverse(on bool) {
+	cy, cx := w.CursorYX()
+	my, mx := w.MaxYX()
+	for y := 0; y < my; y++ {
+		for x := 0; x < mx; x++ {
+			ch := Char(C.mvwinch(w.win, C.int(y), C.int(x)))
+			if ch&A_BLINK == 0 {
+				continue
+			}
+			attr := ch &^ (A_CHARTEXT | A_COLOR | A_REVERSE)
+			if on {
+				attr |= A_REVERSE
+			}
+			C.mvwchgat(w.win, C.int(y), C.int(x), 1, C.attr_t(attr),
+				C.short(C.ncurses_PAIR_NUMBER(C.int(ch))), nil)
+		}
+	}
+	w.Move(cy, cx)
+}
+
+// SetScrollRegion sets the scrolling region of the window to the lines
+// between top and bottom, inclusive. When ScrollOk has been enabled, output
+// past the bottom line of the region scrolls only the lines within the
+// region, leaving the rest of the window untouched
+func (w *Window) SetScrollRegion(top, bottom int) error {
+	if C.wsetscrreg(w.win, C.int(top), C.int(bottom)) == C.ERR {
+		return cursesError("wsetscrreg")
+	}
+	return nil
+}
+
+// Sub creates a new window of height and width at the coordinates y, x.
+// Unlike Derived, these coordinates are relative to the screen rather than
+// to the original window. This window shares memory with the original
+// window so changes made to one window are reflected in the other. It is
+// necessary to call Touch() on this window prior to calling Refresh in
+// order for it to be displayed.
 func (w *Window) Sub(height, width, y, x int) *Window {
-	return &Window{C.subwin(w.win, C.int(height), C.int(width), C.int(y),
+	return &Window{win: C.subwin(w.win, C.int(height), C.int(width), C.int(y),
 		C.int(x))}
 }
 
 // Standend turns off Standout mode, which is equivalent AttrSet(A_NORMAL)
 func (w *Window) Standend() error {
 	if C.ncurses_wstandend(w.win) == C.ERR {
-		return errors.New("Failed to set standend")
+		return cursesError("wstandend")
 	}
 	return nil
 }
 
-// Standout is equivalent to AttrSet(A_STANDOUT)
-func (w *Window) Standout() error {
+// Standout turns standout mode on or off. Turning it on is equivalent to
+// AttrSet(A_STANDOUT) while turning it off is equivalent to Standend().
+// Standout mode is turned on when on is omitted, as for Standout() in
+// earlier versions, or when its first value is true.
+func (w *Window) Standout(on ...bool) error {
+	if len(on) > 0 && !on[0] {
+		return w.Standend()
+	}
 	if C.ncurses_wstandout(w.win) == C.ERR {
-		return errors.New("Failed to set standout")
+		return cursesError("wstandout")
 	}
 	return nil
 }
 
 // Sync updates all parent or child windows which were created via
-// SubWindow() or DerivedWindow(). Argument can be one of: SYNC_DOWN, which
-// syncronizes all parent windows (done by Refresh() by default so should
-// rarely, if ever, need to be called); SYNC_UP, which updates all child
-// windows to match any updates made to the parent; and, SYNC_CURSOR, which
-// updates the cursor position only for all windows to match the parent window
+// Sub() or Derived(). Argument can be one of: SYNC_DOWN, which updates the
+// window to reflect any changes made to its parent windows (done by
+// Refresh() by default so should rarely, if ever, need to be called);
+// SYNC_UP, which updates all parent windows to reflect any changes made to
+// the window; and, SYNC_CURSOR, which updates the cursor position of all
+// parent windows to match the window. See SyncOk to perform SYNC_UP
+// automatically
 func (w *Window) Sync(sync int) {
 	switch sync {
 	case SYNC_DOWN:
@@ -483,6 +1329,13 @@ func (w *Window) Sync(sync int) {
 	}
 }
 
+// SyncOk specifies whether the window should automatically be synchronised
+// with its parent windows whenever it changes, as though Sync(SYNC_UP) were
+// called after every change. Defaults to False.
+func (w *Window) SyncOk(ok bool) {
+	C.syncok(w.win, C.bool(ok))
+}
+
 // Timeout sets the window to blocking or non-blocking read mode. Calls to
 // GetCh will behave in the following manor depending on the value of delay:
 // <= -1 - blocking mode is set (blocks indefinately)
@@ -496,7 +1349,7 @@ func (w *Window) Timeout(delay int) {
 // on the next call to Refresh
 func (w *Window) Touch() error {
 	if C.ncurses_touchwin(w.win) == C.ERR {
-		return errors.New("Failed to Touch window")
+		return cursesError("touchwin")
 	}
 	return nil
 }
@@ -506,11 +1359,13 @@ func (w *Window) Touched() bool {
 	return bool(C.is_wintouched(w.win))
 }
 
-// Touchline behaves like Touch but only effects count number of lines,
-// beginning at start
+// TouchLine behaves like Touch but only effects count number of lines,
+// beginning at start. Use it in place of Touch when only part of a window
+// has changed so that the rest of the window need not be compared on the
+// next Refresh
 func (w *Window) TouchLine(start, count int) error {
 	if C.touchline(w.win, C.int(start), C.int(count)) == C.ERR {
-		return errors.New("Error in call to TouchLine")
+		return cursesError("touchline")
 	}
 	return nil
 }
@@ -522,15 +1377,87 @@ func (w *Window) UnTouch() {
 }
 
 // VLine draws a verticle line starting at y, x and ending at height using
-// the specified character
+// the specified character. As with HLine, ch may carry attributes.
 func (w *Window) VLine(y, x int, ch Char, wid int) {
 	C.mvwvline(w.win, C.int(y), C.int(x), C.chtype(ch), C.int(wid))
 }
 
-// YX returns the current coordinates of the Window. Note that it uses
-// ncurses idiom of returning y then x.
+// Style is a set of attributes and, optionally, a color pair to be applied
+// to a window by With. A Pair of zero (0) leaves the current color unchanged.
+type Style struct {
+	Attrs []Char
+	Pair  int16
+}
+
+// With turns on the attributes and color pair in style, calls fn and then
+// restores the window's attributes and color pair to exactly what they were
+// beforehand, even if fn panics. This prevents styling from leaking into
+// later output when an AttrOff is forgotten.
+func (w *Window) With(style Style, fn func()) (err error) {
+	var attr C.attr_t
+	var pair C.short
+	if C.ncurses_wattr_get(w.win, &attr, &pair) == C.ERR {
+		return cursesError("wattr_get")
+	}
+	defer func() {
+		if C.ncurses_wattr_set(w.win, attr, pair) == C.ERR && err == nil {
+			err = cursesError("wattr_set")
+		}
+	}()
+	if err = w.AttrOn(style.Attrs...); err != nil {
+		return
+	}
+	if style.Pair != 0 {
+		if C.wcolor_set(w.win, C.short(style.Pair), nil) == C.ERR {
+			return cursesError("wcolor_set")
+		}
+	}
+	fn()
+	return
+}
+
+// WriteAt moves the cursor to y, x and prints the message using the
+// specified format. Unlike MovePrintf, an error is returned if the
+// coordinates lie outside the window or the string could not be written.
+func (w *Window) WriteAt(y, x int, format string, args ...interface{}) error {
+	return w.moveAddString(y, x, fmt.Sprintf(format, args...))
+}
+
+// YX returns the current coordinates of the Window on the screen, which is
+// its origin or upper-left corner. Note that it uses ncurses idiom of
+// returning y then x.
 func (w *Window) YX() (int, int) {
 	var y, x C.int
 	C.ncurses_getbegyx(w.win, &y, &x)
 	return int(y), int(x)
 }
+
+// addString writes s at the cursor without any further formatting
+func (w *Window) addString(s string) error {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+
+	if C.waddstr(w.win, cstr) == C.ERR {
+		return cursesError("waddstr")
+	}
+	return nil
+}
+
+// moveAddString writes s at y, x without any further formatting
+func (w *Window) moveAddString(y, x int, s string) error {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+
+	if C.mvwaddstr(w.win, C.int(y), C.int(x), cstr) == C.ERR {
+		return cursesError("mvwaddstr")
+	}
+	return nil
+}
+
+// combineAttrs OR's together a list of attributes
+func combineAttrs(attrs []Char) (attr Char) {
+	for _, a := range attrs {
+		attr |= a
+	}
+	return
+}
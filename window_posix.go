@@ -0,0 +1,76 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows
+
+package goncurses
+
+// #include <curses.h>
+// #include <wchar.h>
+// #include "goncurses.h"
+import "C"
+
+import "time"
+
+// GetWChar retrieves a wide character from the input stream. Unlike GetChar,
+// multi-byte characters entered on a UTF-8 terminal are returned as a single
+// rune. If the input is a function key, such as KEY_DOWN, then isKey will be
+// true and the rune holds the key code, otherwise it is a character. As with
+// ReadChar, ErrInputTimeout is returned if the window is in a delay mode and
+// no input was received, and an error if the input stream failed.
+func (w *Window) GetWChar() (r rune, isKey bool, err error) {
+	var wch C.wint_t
+	start := time.Now()
+	switch C.wget_wch(w.win, &wch) {
+	case C.OK:
+		return rune(wch), false, nil
+	case C.KEY_CODE_YES:
+		return rune(wch), true, nil
+	}
+	return 0, false, w.inputError("wget_wch", start)
+}
+
+// AddRune prints a single wide character to the window with the given
+// attributes OR'd together. Unlike AddChar, any unicode character may be
+// printed provided the locale and terminal support it. The cursor advances
+// by the width of the rune, as reported by RuneWidth. A double-width rune is
+// never split across the right edge of the window: if only one column
+// remains on the line it is left blank and the rune is written on the next.
+func (w *Window) AddRune(r rune, attrs ...Char) error {
+	var cc C.cchar_t
+	wch := []C.wchar_t{C.wchar_t(r), 0}
+	attr := combineAttrs(attrs)
+	if C.setcchar(&cc, &wch[0], C.attr_t(attr&^A_COLOR),
+		C.short(C.ncurses_PAIR_NUMBER(C.int(attr))), nil) == C.ERR {
+		return cursesError("setcchar")
+	}
+	if C.wadd_wch(w.win, &cc) == C.ERR {
+		return cursesError("wadd_wch")
+	}
+	return nil
+}
+
+// PrintRunes prints the UTF-8 encoded string s to the window as wide
+// characters. Unlike Print, no formatting is performed on the string.
+// Double-width runes are handled as described for AddRune.
+func (w *Window) PrintRunes(s string) error {
+	wstr := make([]C.wchar_t, 0, len(s)+1)
+	for _, r := range s {
+		wstr = append(wstr, C.wchar_t(r))
+	}
+	wstr = append(wstr, 0)
+	if C.waddwstr(w.win, &wstr[0]) == C.ERR {
+		return cursesError("waddwstr")
+	}
+	return nil
+}
+
+// RuneWidth returns the number of columns r occupies on the screen: 0 for
+// combining characters, 2 for wide characters such as those used in East
+// Asian scripts and 1 otherwise. -1 is returned if r is not printable. The
+// result depends on the locale, which is set by Init and NewTerm.
+func RuneWidth(r rune) int {
+	return int(C.wcwidth(C.wchar_t(r)))
+}
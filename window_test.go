@@ -0,0 +1,1558 @@
+// goncurses - ncurses library for Go.
+// Copyright 2011 Rob Thornton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goncurses_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rthornton128/goncurses"
+)
+
+// newTestScreen creates an xterm screen which writes to and reads from the
+// null device so that tests can be run without a real terminal. The
+// returned function must be called to end and free the screen.
+func newTestScreen(t testing.TB) (*goncurses.Window, func()) {
+	return newTestTerm(t, "xterm")
+}
+
+// newTestTerm behaves like newTestScreen but for the given terminal type
+func newTestTerm(t testing.TB, termType string) (*goncurses.Window,
+	func()) {
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scr, err := goncurses.NewTerm(termType, out, in)
+	if err != nil {
+		t.Skip("unable to create test screen:", err)
+	}
+	return goncurses.StdScr(), func() {
+		scr.End()
+		scr.Delete()
+		out.Close()
+		in.Close()
+	}
+}
+
+func TestAttrGet(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	if err := goncurses.InitPair(3, goncurses.C_RED,
+		goncurses.C_BLACK); err != nil {
+		t.Fatal(err)
+	}
+	stdscr.AttrOn(goncurses.A_BOLD | goncurses.A_REVERSE)
+	stdscr.ColorOn(3)
+
+	attr, pair, err := stdscr.AttrGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr != goncurses.A_BOLD|goncurses.A_REVERSE {
+		t.Errorf("expected bold and reverse attributes, got %#x", attr)
+	}
+	if pair != 3 {
+		t.Errorf("expected color pair 3, got %d", pair)
+	}
+}
+
+func TestAttrOnMultiple(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.AttrOn(goncurses.A_BOLD, goncurses.A_UNDERLINE)
+	attr, _, _ := stdscr.AttrGet()
+	if attr != goncurses.A_BOLD|goncurses.A_UNDERLINE {
+		t.Errorf("expected bold and underline attributes, got %#x", attr)
+	}
+	stdscr.AttrOff(goncurses.A_BOLD, goncurses.A_UNDERLINE)
+	attr, _, _ = stdscr.AttrGet()
+	if attr != goncurses.A_NORMAL {
+		t.Errorf("expected normal attribute, got %#x", attr)
+	}
+}
+
+func TestChangeAt(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.MovePrint(0, 0, "abcdefgh")
+	if err := stdscr.ChangeAt(0, 2, 4, 0, goncurses.A_BOLD); err != nil {
+		t.Fatal(err)
+	}
+	for x, ch := range "abcdefgh" {
+		c := stdscr.MoveInChar(0, x)
+		if rune(c&goncurses.A_CHARTEXT) != ch {
+			t.Errorf("column %d: expected %c, got %c", x, ch,
+				rune(c&goncurses.A_CHARTEXT))
+		}
+		bold := c&goncurses.A_BOLD != 0
+		if want := x >= 2 && x <= 5; bold != want {
+			t.Errorf("column %d: expected bold to be %v", x, want)
+		}
+	}
+}
+
+func TestInString(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.MovePrint(2, 4, "hello, world")
+	if s := stdscr.MoveInString(2, 4, 5); s != "hello" {
+		t.Errorf("expected \"hello\", got %q", s)
+	}
+	stdscr.Move(2, 11)
+	if s := stdscr.InString(5); s != "world" {
+		t.Errorf("expected \"world\", got %q", s)
+	}
+}
+
+func TestMoveWindow(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(5, 10, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if err := win.MoveWindow(3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if y, x := win.YX(); y != 3 || x != 4 {
+		t.Errorf("expected window at 3, 4, got %d, %d", y, x)
+	}
+	if err := win.MoveWindow(22, 75); err == nil {
+		t.Error("expected error moving window off-screen")
+	}
+}
+
+func TestParYX(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(10, 20, 5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	if y, x := win.ParYX(); y != -1 || x != -1 {
+		t.Errorf("expected -1, -1 for a top-level window, got %d, %d", y, x)
+	}
+
+	sub := win.Derived(3, 3, 1, 2)
+	defer sub.Delete()
+	if y, x := sub.ParYX(); y != 1 || x != 2 {
+		t.Errorf("expected 1, 2 relative to parent, got %d, %d", y, x)
+	}
+	if y, x := sub.YX(); y != 6 || x != 7 {
+		t.Errorf("expected origin at 6, 7, got %d, %d", y, x)
+	}
+}
+
+func TestDerivedAndSub(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(10, 20, 5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	der := win.Derived(3, 3, 1, 1)
+	defer der.Delete()
+	if y, x := der.YX(); y != 6 || x != 6 {
+		t.Errorf("Derived: expected origin at 6, 6, got %d, %d", y, x)
+	}
+	sub := win.Sub(3, 3, 6, 6)
+	defer sub.Delete()
+	if y, x := sub.YX(); y != 6 || x != 6 {
+		t.Errorf("Sub: expected origin at 6, 6, got %d, %d", y, x)
+	}
+}
+
+func TestParent(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(10, 20, 5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	if p := win.Parent(); p != nil {
+		t.Error("expected no parent for a top-level window")
+	}
+
+	sub := win.Derived(5, 10, 1, 1)
+	defer sub.Delete()
+	subsub := sub.Derived(2, 2, 1, 1)
+	defer subsub.Delete()
+	p := subsub.Parent()
+	if p == nil || *p != *sub {
+		t.Fatal("expected sub-window's parent to be the derived window")
+	}
+	if p = p.Parent(); p == nil || *p != *win {
+		t.Error("expected grandparent to be the top-level window")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	src, err := goncurses.NewWindow(1, 5, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Delete()
+	dst, err := goncurses.NewWindow(1, 5, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Delete()
+
+	src.MovePrint(0, 0, "a b c")
+	dst.MovePrint(0, 0, "vwxyz")
+	if err := dst.Overlay(src); err != nil {
+		t.Fatal(err)
+	}
+	if s := dst.MoveInString(0, 0, 5); s != "awbyc" {
+		t.Errorf("expected \"awbyc\", got %q", s)
+	}
+	if err := dst.Overwrite(src); err != nil {
+		t.Fatal(err)
+	}
+	if s := dst.MoveInString(0, 0, 5); s != "a b c" {
+		t.Errorf("expected \"a b c\", got %q", s)
+	}
+}
+
+func TestTouchLine(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(6, 10, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	win.UnTouch()
+	if win.Touched() {
+		t.Fatal("expected window to be untouched")
+	}
+	if err := win.TouchLine(2, 2); err != nil {
+		t.Fatal(err)
+	}
+	for line := 0; line < 6; line++ {
+		want := line == 2 || line == 3
+		if win.LineTouched(line) != want {
+			t.Errorf("line %d: expected touched to be %v", line, want)
+		}
+	}
+	if !win.Touched() {
+		t.Error("expected window to be touched")
+	}
+}
+
+func TestIsCleared(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Refresh()
+	if stdscr.IsCleared() {
+		t.Error("expected clear flag to be off")
+	}
+	stdscr.ClearOk(true)
+	if !stdscr.IsCleared() {
+		t.Error("expected clear flag to be on")
+	}
+	stdscr.Refresh()
+	if stdscr.IsCleared() {
+		t.Error("expected clear flag to be reset by Refresh")
+	}
+}
+
+func TestGetStringInto(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	buf := make([]byte, 4)
+	for _, ch := range "\nabc" {
+		goncurses.UnGetChar(goncurses.Char(ch))
+	}
+	s, err := stdscr.GetStringInto(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "cba" {
+		t.Errorf("expected \"cba\", got %q", s)
+	}
+}
+
+func TestReadCharTimeout(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	goncurses.UnGetChar('a')
+	if k, err := stdscr.ReadChar(); err != nil || k != 'a' {
+		t.Errorf("expected 'a', got %v, %v", k, err)
+	}
+
+	stdscr.Timeout(0)
+	if _, err := stdscr.ReadChar(); err != goncurses.ErrInputTimeout {
+		t.Errorf("expected ErrInputTimeout, got %v", err)
+	}
+
+	stdscr.Timeout(-1)
+	_, err := stdscr.ReadChar()
+	if err == nil || err == goncurses.ErrInputTimeout {
+		t.Errorf("expected input stream error, got %v", err)
+	}
+}
+
+func TestReadCharEndOfInput(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	// the null device is always at end of file, so reads fail at once
+	// rather than timing out
+	stdscr.Timeout(500)
+	var cerr *goncurses.CursesError
+	if _, err := stdscr.ReadChar(); !errors.As(err, &cerr) {
+		t.Errorf("ReadChar: expected a CursesError, got %v", err)
+	}
+	if _, _, err := stdscr.GetWChar(); !errors.As(err, &cerr) {
+		t.Errorf("GetWChar: expected a CursesError, got %v", err)
+	}
+
+	stdscr.Timeout(-1)
+	if err := goncurses.HalfDelay(5); err != nil {
+		t.Fatal(err)
+	}
+	defer goncurses.CBreak(true)
+	if _, err := stdscr.ReadChar(); !errors.As(err, &cerr) {
+		t.Errorf("half-delay: expected a CursesError, got %v", err)
+	}
+}
+
+func TestGetKey(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	for _, k := range []goncurses.Key{'x', goncurses.KEY_DOWN} {
+		goncurses.UnGetChar(goncurses.Char(k))
+	}
+	for _, want := range []string{"down", "x"} {
+		name, err := stdscr.GetKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != want {
+			t.Errorf("expected %q, got %q", want, name)
+		}
+	}
+}
+
+func TestSetScrollRegion(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(8, 4, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	for y := 0; y < 8; y++ {
+		win.MovePrint(y, 0, y)
+	}
+	if top, bottom := win.ScrollRegion(); top != 0 || bottom != 7 {
+		t.Errorf("expected default region 0, 7, got %d, %d", top, bottom)
+	}
+	if win.IsScrollOk() {
+		t.Error("expected scrolling to be off by default")
+	}
+	win.ScrollOk(true)
+	if !win.IsScrollOk() {
+		t.Error("expected scrolling to be on")
+	}
+	if err := win.SetScrollRegion(2, 5); err != nil {
+		t.Fatal(err)
+	}
+	if top, bottom := win.ScrollRegion(); top != 2 || bottom != 5 {
+		t.Errorf("expected region 2, 5, got %d, %d", top, bottom)
+	}
+	win.Scroll(1)
+
+	for y, want := range []string{"0", "1", "3", "4", "5", " ", "6", "7"} {
+		if s := win.MoveInString(y, 0, 1); s != want {
+			t.Errorf("line %d: expected %q, got %q", y, want, s)
+		}
+	}
+}
+
+func TestGetWChar(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Keypad(true)
+	goncurses.UnGetChar(goncurses.Char(goncurses.KEY_DOWN))
+	goncurses.UnGetChar('a')
+	r, isKey, err := stdscr.GetWChar()
+	if err != nil || isKey || r != 'a' {
+		t.Errorf("expected character 'a', got %q, %v, %v", r, isKey, err)
+	}
+	r, isKey, err = stdscr.GetWChar()
+	if err != nil || !isKey || r != goncurses.KEY_DOWN {
+		t.Errorf("expected KEY_DOWN, got %d, %v, %v", r, isKey, err)
+	}
+}
+
+func TestAddRune(t *testing.T) {
+	t.Setenv("LC_ALL", "C.UTF-8")
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Move(0, 0)
+	for _, r := range "café" {
+		if err := stdscr.AddRune(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stdscr.PrintRunes(" → ok"); err != nil {
+		t.Fatal(err)
+	}
+	s := strings.TrimRight(stdscr.MoveInString(0, 0, 16), " ")
+	if s != "café → ok" {
+		t.Errorf("expected \"café → ok\", got %q", s)
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	t.Setenv("LC_ALL", "C.UTF-8")
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	for _, test := range []struct {
+		r     rune
+		width int
+	}{{'a', 1}, {'é', 1}, {'世', 2}, {'\u0301', 0}, {'\x01', -1}} {
+		if w := goncurses.RuneWidth(test.r); w != test.width {
+			t.Errorf("%q: expected width %d, got %d", test.r, test.width, w)
+		}
+	}
+
+	// a double-width rune at the last column wraps rather than being split
+	_, mx := stdscr.MaxYX()
+	stdscr.Move(0, mx-1)
+	if err := stdscr.AddRune('世'); err != nil {
+		t.Fatal(err)
+	}
+	if c := stdscr.MoveInChar(0, mx-1) & goncurses.A_CHARTEXT; c != ' ' {
+		t.Errorf("expected last column to be blank, got %#x", c)
+	}
+	if s := strings.TrimRight(stdscr.MoveInString(1, 0, 4), " "); s != "世" {
+		t.Errorf("expected rune on the next line, got %q", s)
+	}
+	stdscr.Move(1, 0)
+	stdscr.AddRune('世')
+	if _, x := stdscr.CursorYX(); x != 2 {
+		t.Errorf("expected cursor to advance two columns, got %d", x)
+	}
+}
+
+func TestBoxDefaults(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(4, 6, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if err := win.Box(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if ch := win.MoveInChar(1, 0); ch != goncurses.ACS(goncurses.ACS_VLINE) {
+		t.Errorf("expected vertical line, got %#x", ch)
+	}
+	if ch := win.MoveInChar(0, 1); ch != goncurses.ACS(goncurses.ACS_HLINE) {
+		t.Errorf("expected horizontal line, got %#x", ch)
+	}
+}
+
+func TestBorderDefault(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(5, 10, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if err := win.BorderDefault(); err != nil {
+		t.Fatal(err)
+	}
+	corners := []struct {
+		y, x int
+		ch   goncurses.Char
+	}{
+		{0, 0, goncurses.ACS_ULCORNER},
+		{0, 9, goncurses.ACS_URCORNER},
+		{4, 0, goncurses.ACS_LLCORNER},
+		{4, 9, goncurses.ACS_LRCORNER},
+	}
+	for _, c := range corners {
+		if ch := win.MoveInChar(c.y, c.x); ch != goncurses.ACS(c.ch) {
+			t.Errorf("%d, %d: expected %#x, got %#x", c.y, c.x,
+				goncurses.ACS(c.ch), ch)
+		}
+	}
+}
+
+func TestCoordinates(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(7, 12, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if y, x := win.MaxYX(); y != 7 || x != 12 {
+		t.Errorf("expected size 7, 12, got %d, %d", y, x)
+	}
+	if y, x := win.YX(); y != 3 || x != 5 {
+		t.Errorf("expected origin 3, 5, got %d, %d", y, x)
+	}
+	win.Move(2, 4)
+	if y, x := win.CursorYX(); y != 2 || x != 4 {
+		t.Errorf("expected cursor at 2, 4, got %d, %d", y, x)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(3, 3, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := win.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := win.Delete(); err == nil {
+		t.Error("expected error deleting window twice")
+	}
+	if err := win.Clear(); err == nil {
+		t.Error("expected error using deleted window")
+	}
+}
+
+func TestClose(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(3, 3, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := win.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := win.Close(); err != nil {
+		t.Errorf("expected second Close to do nothing, got %v", err)
+	}
+	if err := win.Delete(); err == nil {
+		t.Error("expected error deleting closed window")
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(2, 8, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	win.MovePrint(1, 1, "copy me")
+
+	dup, err := win.Duplicate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dup.Delete()
+	if s := dup.MoveInString(1, 1, 7); s != "copy me" {
+		t.Errorf("expected \"copy me\", got %q", s)
+	}
+}
+
+func TestSetBackground(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	goncurses.InitPair(1, goncurses.C_WHITE, goncurses.C_BLUE)
+
+	win, err := goncurses.NewWindow(2, 2, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	tests := []goncurses.Char{
+		'.',
+		goncurses.A_REVERSE | ' ',
+		goncurses.ColorPair(1) | ' ',
+		goncurses.ColorPair(1) | goncurses.A_BOLD | '#',
+	}
+	for _, bg := range tests {
+		win.SetBackground(bg)
+		if got := win.Background(); got != bg {
+			t.Errorf("expected background %#x, got %#x", bg, got)
+		}
+		if ch := win.MoveInChar(1, 1); ch != bg {
+			t.Errorf("expected cell to be %#x, got %#x", bg, ch)
+		}
+	}
+}
+
+func TestSetBackgroundChar(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(1, 4, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	win.MovePrint(0, 0, "ab")
+	win.SetBackgroundChar('.')
+	if s := win.MoveInString(0, 0, 4); s != "ab  " {
+		t.Errorf("expected existing contents unchanged, got %q", s)
+	}
+	win.Move(0, 1)
+	win.ClearToEOL()
+	if s := win.MoveInString(0, 0, 4); s != "a..." {
+		t.Errorf("expected \"a...\", got %q", s)
+	}
+}
+
+func TestSetBlinkFallback(t *testing.T) {
+	for _, test := range []struct {
+		term     string
+		simulate bool
+	}{
+		{"vt52", true},
+		{"xterm", false},
+	} {
+		stdscr, end := newTestTerm(t, test.term)
+
+		stdscr.SetBlinkFallback(true)
+		stdscr.AttrOn(goncurses.A_BLINK)
+		stdscr.MovePrint(0, 0, "x")
+		for i := 0; i < 4; i++ {
+			stdscr.Refresh()
+			reverse := stdscr.MoveInChar(0, 0)&goncurses.A_REVERSE != 0
+			if want := test.simulate && i%2 == 0; reverse != want {
+				t.Errorf("%s refresh %d: expected reverse to be %v",
+					test.term, i, want)
+			}
+		}
+		end()
+	}
+}
+
+func TestBlinkFallbackNotInherited(t *testing.T) {
+	stdscr, end := newTestTerm(t, "vt52")
+	for i := 0; i < 8; i++ {
+		win, err := goncurses.NewWindow(2, 2, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		win.SetBlinkFallback(true)
+	}
+	stdscr.SetBlinkFallback(true)
+	end()
+
+	// the windows of the new screen may be allocated at the same addresses
+	// as those of the deleted one, including the ones never deleted
+	stdscr, end = newTestTerm(t, "vt52")
+	defer end()
+	windows := []*goncurses.Window{stdscr}
+	for i := 0; i < 8; i++ {
+		win, err := goncurses.NewWindow(2, 2, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer win.Delete()
+		windows = append(windows, win)
+	}
+	for i, w := range windows {
+		w.AttrOn(goncurses.A_BLINK)
+		w.MovePrint(0, 0, "x")
+		w.Refresh()
+		if w.MoveInChar(0, 0)&goncurses.A_REVERSE != 0 {
+			t.Errorf("window %d: expected blinking not to be simulated", i)
+		}
+	}
+}
+
+func TestWriteAt(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := stdscr.WriteAt(2, 3, "%d%% done", 50); err != nil {
+		t.Fatal(err)
+	}
+	if y, x := stdscr.CursorYX(); y != 2 || x != 11 {
+		t.Errorf("expected cursor at 2, 11, got %d, %d", y, x)
+	}
+	if s := stdscr.MoveInString(2, 3, 8); s != "50% done" {
+		t.Errorf("expected \"50%% done\", got %q", s)
+	}
+	rows, _ := stdscr.MaxYX()
+	if err := stdscr.WriteAt(rows, 0, "x"); err == nil {
+		t.Error("expected error writing outside of window")
+	}
+}
+
+func TestPrintPercent(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Print("50% done")
+	if s := stdscr.MoveInString(0, 0, 8); s != "50% done" {
+		t.Errorf("Print: expected \"50%% done\", got %q", s)
+	}
+	stdscr.MovePrint(1, 0, "100%", " ", 7)
+	if s := stdscr.MoveInString(1, 0, 6); s != "100% 7" {
+		t.Errorf("MovePrint: expected \"100%% 7\", got %q", s)
+	}
+	verb := "%d"
+	stdscr.MovePrintln(2, 0, verb)
+	if s := stdscr.MoveInString(2, 0, 2); s != verb {
+		t.Errorf("MovePrintln: expected %q, got %q", verb, s)
+	}
+	untrusted := "100% %s done"
+	stdscr.Move(3, 0)
+	stdscr.Print(untrusted)
+	if s := stdscr.MoveInString(3, 0, len(untrusted)); s != untrusted {
+		t.Errorf("Print: expected %q, got %q", untrusted, s)
+	}
+}
+
+func TestPrintln(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(2, 10, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	win.Println("one")
+	if y, x := win.CursorYX(); y != 1 || x != 0 {
+		t.Errorf("expected cursor at 1, 0, got %d, %d", y, x)
+	}
+	win.ScrollOk(true)
+	win.Println("two")
+	if s := win.MoveInString(0, 0, 3); s != "two" {
+		t.Errorf("expected window to scroll, got %q on first line", s)
+	}
+}
+
+func TestAddChars(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	chars := []goncurses.Char{'a' | goncurses.A_BOLD, 'b',
+		'c' | goncurses.A_UNDERLINE}
+	stdscr.Move(1, 2)
+	if err := stdscr.AddChars(chars); err != nil {
+		t.Fatal(err)
+	}
+	if y, x := stdscr.CursorYX(); y != 1 || x != 2 {
+		t.Errorf("expected cursor to remain at 1, 2, got %d, %d", y, x)
+	}
+	for i, ch := range chars {
+		if c := stdscr.MoveInChar(1, 2+i); c != ch {
+			t.Errorf("expected %#x at column %d, got %#x", ch, 2+i, c)
+		}
+	}
+	if err := stdscr.AddChars(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// benchmarkLine returns a line of bold characters as wide as stdscr
+func benchmarkLine(stdscr *goncurses.Window) []goncurses.Char {
+	_, cols := stdscr.MaxYX()
+	line := make([]goncurses.Char, cols)
+	for i := range line {
+		line[i] = goncurses.Char('a'+i%26) | goncurses.A_BOLD
+	}
+	return line
+}
+
+func BenchmarkAddCharLoop(b *testing.B) {
+	stdscr, end := newTestScreen(b)
+	defer end()
+	line := benchmarkLine(stdscr)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stdscr.Move(0, 0)
+		for _, ch := range line[:len(line)-1] {
+			stdscr.AddChar(ch)
+		}
+	}
+}
+
+func BenchmarkAddChars(b *testing.B) {
+	stdscr, end := newTestScreen(b)
+	defer end()
+	line := benchmarkLine(stdscr)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stdscr.Move(0, 0)
+		stdscr.AddChars(line[:len(line)-1])
+	}
+}
+
+func TestInChars(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	chars := []goncurses.Char{'x' | goncurses.A_REVERSE, 'y',
+		'z' | goncurses.A_BOLD}
+	stdscr.Move(3, 0)
+	stdscr.AddChars(chars)
+	got := stdscr.InChars(len(chars))
+	if len(got) != len(chars) {
+		t.Fatalf("expected %d characters, got %d", len(chars), len(got))
+	}
+	for i := range chars {
+		if got[i] != chars[i] {
+			t.Errorf("expected %#x at index %d, got %#x", chars[i], i, got[i])
+		}
+	}
+	_, cols := stdscr.MaxYX()
+	stdscr.Move(3, cols-2)
+	if got := stdscr.InChars(10); len(got) != 2 {
+		t.Errorf("expected 2 characters at right edge, got %d", len(got))
+	}
+}
+
+func TestMeta(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	// IntrFlush changes the terminal driver settings so can't be tested
+	// against the null device
+	for _, on := range []bool{true, false} {
+		if err := stdscr.Meta(on); err != nil {
+			t.Errorf("Meta(%v): %v", on, err)
+		}
+	}
+}
+
+func TestNoTimeout(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Keypad(true)
+	for _, on := range []bool{true, false} {
+		if err := stdscr.NoTimeout(on); err != nil {
+			t.Errorf("NoTimeout(%v): %v", on, err)
+		}
+	}
+}
+
+func TestGetRawBytes(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Keypad(true)
+	stdscr.Timeout(0)
+	for _, ch := range []goncurses.Char{'A', '[', 27} {
+		goncurses.UnGetChar(ch)
+	}
+	b, err := stdscr.GetRawBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "\x1b[A" {
+		t.Errorf("expected escape sequence for up arrow, got %q", b)
+	}
+	if !stdscr.IsKeypad() {
+		t.Error("expected keypad to be restored")
+	}
+	if _, err := stdscr.GetRawBytes(); err != goncurses.ErrInputTimeout {
+		t.Errorf("expected ErrInputTimeout, got %v", err)
+	}
+}
+
+func TestEchoChar(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Refresh()
+	stdscr.Move(1, 1)
+	if err := stdscr.EchoChar('x' | goncurses.A_BOLD); err != nil {
+		t.Fatal(err)
+	}
+	if c := stdscr.MoveInChar(1, 1); c != 'x'|goncurses.A_BOLD {
+		t.Errorf("expected bold x, got %#x", c)
+	}
+	if stdscr.LineTouched(1) {
+		t.Error("expected line to have been refreshed")
+	}
+}
+
+func TestFill(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Move(0, 0)
+	if err := stdscr.Fill(2, 3, 3, 5, '#', goncurses.A_REVERSE); err != nil {
+		t.Fatal(err)
+	}
+	for y := 1; y <= 5; y++ {
+		for x := 2; x <= 8; x++ {
+			want := goncurses.Char(' ')
+			if y >= 2 && y < 5 && x >= 3 && x < 8 {
+				want = '#' | goncurses.A_REVERSE
+			}
+			if c := stdscr.MoveInChar(y, x); c != want {
+				t.Errorf("%d, %d: expected %#x, got %#x", y, x, want, c)
+			}
+		}
+	}
+
+	stdscr.Move(0, 0)
+	rows, cols := stdscr.MaxYX()
+	if err := stdscr.Fill(rows-1, cols-2, 5, 5, '*'); err != nil {
+		t.Fatal(err)
+	}
+	if y, x := stdscr.CursorYX(); y != 0 || x != 0 {
+		t.Errorf("expected cursor to remain at 0, 0, got %d, %d", y, x)
+	}
+	if s := stdscr.MoveInString(rows-1, cols-3, 3); s != " **" {
+		t.Errorf("expected fill clipped to window, got %q", s)
+	}
+}
+
+func TestClone(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(4, 10, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	win.MovePrint(1, 1, "clone")
+
+	clone, err := win.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clone.Delete()
+	if y, x := clone.YX(); y != 2 || x != 3 {
+		t.Errorf("expected clone at 2, 3, got %d, %d", y, x)
+	}
+	if s := clone.MoveInString(1, 1, 5); s != "clone" {
+		t.Errorf("expected \"clone\", got %q", s)
+	}
+
+	sub := win.Derived(2, 5, 1, 1)
+	defer sub.Delete()
+	if _, err := sub.Clone(); err == nil {
+		t.Error("expected error cloning a sub-window")
+	}
+}
+
+func TestGetCharContext(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	goncurses.UnGetChar('q')
+	k, err := stdscr.GetCharContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k != 'q' {
+		t.Errorf("expected q, got %d", k)
+	}
+
+	// the null device is always at end of file
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = stdscr.GetCharContext(ctx)
+	var cerr *goncurses.CursesError
+	if !errors.As(err, &cerr) {
+		t.Errorf("expected a CursesError at end of input, got %v", err)
+	}
+}
+
+func TestGetCharContextTimeout(t *testing.T) {
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	// input from a pipe which is kept open blocks rather than ending
+	in, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	defer w.Close()
+	scr, err := goncurses.NewTerm("xterm", out, in)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer scr.Delete()
+	defer scr.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		10*time.Millisecond)
+	defer cancel()
+	stdscr := goncurses.StdScr()
+	_, err = stdscr.GetCharContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// unlike at end of file, ReadChar times out when no input arrives
+	stdscr.Timeout(20)
+	if _, err := stdscr.ReadChar(); err != goncurses.ErrInputTimeout {
+		t.Errorf("expected ErrInputTimeout, got %v", err)
+	}
+}
+
+// unGetString pushes s onto the input queue so that it is read in order
+func unGetString(s string) {
+	r := []rune(s)
+	for i := len(r) - 1; i >= 0; i-- {
+		goncurses.UnGetChar(goncurses.Char(r[i]))
+	}
+}
+
+func TestGetPassword(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	unGetString("secret\n")
+	s, err := stdscr.GetPassword(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "secret" {
+		t.Errorf("expected \"secret\", got %q", s)
+	}
+	if s := stdscr.MoveInString(0, 0, 6); s != "      " {
+		t.Errorf("expected password not to be echoed, got %q", s)
+	}
+
+	stdscr.Move(0, 0)
+	unGetString("shown\n")
+	stdscr.GetString(10)
+	if s := stdscr.MoveInString(0, 0, 5); s != "shown" {
+		t.Errorf("expected echo to be restored, got %q", s)
+	}
+}
+
+func TestGetMasked(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	unGetString("abx\x7fc\n")
+	s, err := stdscr.GetMasked(10, '*')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "abc" {
+		t.Errorf("expected \"abc\", got %q", s)
+	}
+	if s := stdscr.MoveInString(0, 0, 4); s != "*** " {
+		t.Errorf("expected \"*** \" on screen, got %q", s)
+	}
+
+	stdscr.Move(1, 0)
+	unGetString("abcdef\n")
+	if s, _ := stdscr.GetMasked(3, '#'); s != "abc" {
+		t.Errorf("expected input limited to \"abc\", got %q", s)
+	}
+
+	win, err := goncurses.NewWindow(1, 4, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+	unGetString("abcdef\n")
+	if s, _ := win.GetMasked(10, '*'); s != "abc" {
+		t.Errorf("expected input limited by window width, got %q", s)
+	}
+}
+
+func TestForceRepaint(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Print("repaint")
+	if err := stdscr.ForceRepaint(); err != nil {
+		t.Fatal(err)
+	}
+	if stdscr.IsCleared() {
+		t.Error("expected clear flag to be reset by the refresh")
+	}
+	if stdscr.Touched() {
+		t.Error("expected window to have been refreshed")
+	}
+}
+
+func TestGetKeyEvent(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	stdscr.Timeout(0)
+	for _, test := range []struct {
+		key goncurses.Key
+		fn  bool
+	}{
+		{'a', false},
+		{0xff, false},
+		{goncurses.KEY_DOWN, true},
+		{goncurses.KEY_F12, true},
+		{goncurses.KEY_RESIZE, true},
+	} {
+		goncurses.UnGetChar(goncurses.Char(test.key))
+		k, fn, err := stdscr.GetKeyEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k != test.key || fn != test.fn {
+			t.Errorf("expected %d, %v, got %d, %v", test.key, test.fn, k, fn)
+		}
+	}
+	if _, _, err := stdscr.GetKeyEvent(); err != goncurses.ErrInputTimeout {
+		t.Errorf("expected ErrInputTimeout, got %v", err)
+	}
+}
+
+func TestColor(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	goncurses.InitPair(5, goncurses.C_BLUE, goncurses.C_BLACK)
+	stdscr.Color(5)
+	if _, p, _ := stdscr.AttrGet(); p != 5 {
+		t.Errorf("expected pair 5, got %d", p)
+	}
+	stdscr.MoveAddChar(0, 0, 'b')
+	if c := stdscr.MoveInChar(0, 0); c&goncurses.A_COLOR !=
+		goncurses.ColorPair(5) {
+		t.Errorf("expected color pair 5, got %#x", c&goncurses.A_COLOR)
+	}
+}
+
+func TestSetColor(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	if err := stdscr.SetColor("red", "black"); err != nil {
+		t.Fatal(err)
+	}
+	stdscr.MoveAddChar(0, 0, 'r')
+	pair, _ := goncurses.AllocPair(goncurses.C_RED, goncurses.C_BLACK)
+	if c := stdscr.MoveInChar(0, 0); c&goncurses.A_COLOR !=
+		goncurses.ColorPair(pair) {
+		t.Errorf("expected color pair %d, got %#x", pair,
+			c&goncurses.A_COLOR)
+	}
+	if err := stdscr.SetColor("purple", "black"); err !=
+		goncurses.ErrBadFGColor {
+		t.Errorf("expected ErrBadFGColor, got %v", err)
+	}
+	if err := stdscr.SetColor("red", "purple"); err !=
+		goncurses.ErrBadBGColor {
+		t.Errorf("expected ErrBadBGColor, got %v", err)
+	}
+}
+
+func TestLineAttributes(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	goncurses.InitPair(2, goncurses.C_GREEN, goncurses.C_BLACK)
+	ch := goncurses.Char('-') | goncurses.A_BOLD | goncurses.ColorPair(2)
+	stdscr.HLine(0, 0, ch, 5)
+	stdscr.VLine(1, 0, ch, 3)
+	for _, pos := range [][2]int{{0, 0}, {0, 4}, {1, 0}, {3, 0}} {
+		if c := stdscr.MoveInChar(pos[0], pos[1]); c != ch {
+			t.Errorf("%d, %d: expected %#x, got %#x", pos[0], pos[1], ch, c)
+		}
+	}
+	if c := stdscr.MoveInChar(0, 5); c&goncurses.A_BOLD != 0 {
+		t.Error("expected line to end after its width")
+	}
+}
+
+func TestDrawBox(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(3, 12, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	for _, test := range []struct {
+		title string
+		align int
+		top   string
+	}{
+		{"Title", goncurses.ALIGN_LEFT, "Title     "},
+		{"Title", goncurses.ALIGN_CENTER, "  Title   "},
+		{"Title", goncurses.ALIGN_RIGHT, "     Title"},
+		{"A much longer title", goncurses.ALIGN_CENTER, "A much lon"},
+		{"", goncurses.ALIGN_LEFT, "          "},
+	} {
+		win.Erase()
+		if err := win.DrawBox(test.title, test.align); err != nil {
+			t.Fatal(err)
+		}
+		// the border itself is drawn with the alternate character set
+		top := make([]rune, 0, 10)
+		for x := 1; x < 11; x++ {
+			c := win.MoveInChar(0, x)
+			if c&goncurses.A_ALTCHARSET != 0 {
+				c = ' '
+			}
+			top = append(top, rune(c&goncurses.A_CHARTEXT))
+		}
+		if string(top) != test.top {
+			t.Errorf("%q: expected top border %q, got %q", test.title,
+				test.top, string(top))
+		}
+	}
+	if err := win.DrawBox("x", 5); err == nil {
+		t.Error("expected error for invalid alignment")
+	}
+
+	// windows too narrow to hold any of the title
+	for _, width := range []int{1, 2} {
+		narrow, err := goncurses.NewWindow(3, width, 4, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, title := range []string{"", "Title"} {
+			if err := narrow.DrawBox(title, goncurses.ALIGN_LEFT); err != nil {
+				t.Errorf("width %d, %q: %v", width, title, err)
+			}
+		}
+		narrow.Delete()
+	}
+}
+
+func TestWith(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if err := goncurses.StartColor(); err != nil {
+		t.Skip(err)
+	}
+	goncurses.InitPair(1, goncurses.C_RED, goncurses.C_BLACK)
+	goncurses.InitPair(2, goncurses.C_GREEN, goncurses.C_BLACK)
+	stdscr.AttrOn(goncurses.A_UNDERLINE)
+	stdscr.Color(1)
+
+	outer := goncurses.Style{Attrs: []goncurses.Char{goncurses.A_BOLD}}
+	inner := goncurses.Style{Attrs: []goncurses.Char{goncurses.A_REVERSE},
+		Pair: 2}
+	err := stdscr.With(outer, func() {
+		stdscr.MoveAddChar(0, 0, 'a')
+		stdscr.With(inner, func() {
+			stdscr.MoveAddChar(0, 1, 'b')
+		})
+		stdscr.MoveAddChar(0, 2, 'c')
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdscr.MoveAddChar(0, 3, 'd')
+
+	tests := []goncurses.Char{
+		'a' | goncurses.A_UNDERLINE | goncurses.A_BOLD | goncurses.ColorPair(1),
+		'b' | goncurses.A_UNDERLINE | goncurses.A_BOLD | goncurses.A_REVERSE |
+			goncurses.ColorPair(2),
+		'c' | goncurses.A_UNDERLINE | goncurses.A_BOLD | goncurses.ColorPair(1),
+		'd' | goncurses.A_UNDERLINE | goncurses.ColorPair(1),
+	}
+	for x, want := range tests {
+		if c := stdscr.MoveInChar(0, x); c != want {
+			t.Errorf("%d: expected %#x, got %#x", x, want, c)
+		}
+	}
+	if attr, pair, _ := stdscr.AttrGet(); attr != goncurses.A_UNDERLINE ||
+		pair != 1 {
+		t.Errorf("expected underline and pair 1 restored, got %#x, %d", attr,
+			pair)
+	}
+}
+
+func TestSingleCoordinates(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(4, 9, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if y, x := win.MaxY(), win.MaxX(); y != 4 || x != 9 {
+		t.Errorf("expected size 4, 9, got %d, %d", y, x)
+	}
+	win.Move(2, 5)
+	if y, x := win.CurY(), win.CurX(); y != 2 || x != 5 {
+		t.Errorf("expected cursor at 2, 5, got %d, %d", y, x)
+	}
+}
+
+func TestPrintWrapped(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	text := "The quick brown fox jumps over the lazy dog. " +
+		"Supercalifragilistic words are broken.\nNew paragraph"
+	want := []string{
+		"The quick",
+		"brown fox",
+		"jumps",
+		"over the",
+		"lazy dog.",
+		"Supercali",
+		"fragilist",
+		"ic words",
+		"are",
+		"broken.",
+		"New",
+		"paragraph",
+	}
+	if rows := stdscr.PrintWrapped(1, 2, 9, text); rows != len(want) {
+		t.Errorf("expected %d rows, got %d", len(want), rows)
+	}
+	for i, line := range want {
+		s := strings.TrimRight(stdscr.MoveInString(1+i, 0, 12), " ")
+		if s != "  "+line {
+			t.Errorf("row %d: expected %q, got %q", i, "  "+line, s)
+		}
+	}
+
+	my, _ := stdscr.MaxYX()
+	if rows := stdscr.PrintWrapped(my-2, 0, 9, text); rows != 2 {
+		t.Errorf("expected output to stop at the bottom, got %d rows", rows)
+	}
+
+	// lines are narrowed rather than running past the right edge
+	stdscr.Erase()
+	_, mx := stdscr.MaxYX()
+	if rows := stdscr.PrintWrapped(0, mx-5, 20,
+		"aaaa bbbb cccc dddd eeee ffff"); rows != 6 {
+		t.Errorf("expected 6 rows at the right edge, got %d", rows)
+	}
+	for y, word := range []string{"aaaa", "bbbb", "cccc", "dddd", "eeee",
+		"ffff"} {
+		if s := stdscr.MoveInString(y, mx-5, 5); s != word+" " {
+			t.Errorf("row %d: expected %q, got %q", y, word+" ", s)
+		}
+	}
+	if rows := stdscr.PrintWrapped(0, mx, 20, "x"); rows != 0 {
+		t.Errorf("expected no rows past the right edge, got %d", rows)
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	if names := stdscr.Attributes(); len(names) != 0 {
+		t.Errorf("expected no attributes, got %v", names)
+	}
+	stdscr.AttrOn(goncurses.A_UNDERLINE, goncurses.A_BOLD)
+	names := stdscr.Attributes()
+	if len(names) != 2 || names[0] != "bold" || names[1] != "underline" {
+		t.Errorf("expected [bold underline], got %v", names)
+	}
+}
+
+func TestResize(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(5, 10, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if err := win.Resize(3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if y, x := win.MaxYX(); y != 3 || x != 4 {
+		t.Errorf("expected size 3, 4, got %d, %d", y, x)
+	}
+	for _, size := range [][2]int{{0, 0}, {-1, 4}, {3, 0}} {
+		if err := win.Resize(size[0], size[1]); err == nil {
+			t.Errorf("%d, %d: expected error", size[0], size[1])
+		}
+	}
+	if y, x := win.MaxYX(); y != 3 || x != 4 {
+		t.Errorf("expected size to be unchanged, got %d, %d", y, x)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	newWin := func(h, w, y, x int) *goncurses.Window {
+		win, err := goncurses.NewWindow(h, w, y, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return win
+	}
+	a := newWin(5, 10, 2, 4)
+	defer a.Delete()
+	b := newWin(4, 4, 5, 12)
+	defer b.Delete()
+	c := newWin(2, 2, 0, 0)
+	defer c.Delete()
+	d := newWin(3, 3, 2, 14)
+	defer d.Delete()
+
+	if !a.Overlaps(b) || !b.Overlaps(a) {
+		t.Error("expected a and b to overlap")
+	}
+	if y, x, h, w, ok := a.Intersection(b); !ok || y != 5 || x != 12 ||
+		h != 2 || w != 2 {
+		t.Errorf("expected 5, 12, 2, 2, got %d, %d, %d, %d, %v", y, x, h, w,
+			ok)
+	}
+	// c is disjoint and d touches a's right edge without overlapping it
+	for _, win := range []*goncurses.Window{c, d} {
+		if a.Overlaps(win) {
+			t.Error("expected windows not to overlap")
+		}
+		if _, _, _, _, ok := a.Intersection(win); ok {
+			t.Error("expected no intersection")
+		}
+	}
+}
+
+func TestScrollUpDown(t *testing.T) {
+	_, end := newTestScreen(t)
+	defer end()
+
+	win, err := goncurses.NewWindow(3, 5, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Delete()
+
+	if err := win.ScrollUp(1); err == nil {
+		t.Error("expected error when scrolling is not enabled")
+	}
+	win.ScrollOk(true)
+	if err := win.ScrollDown(-1); err == nil {
+		t.Error("expected error for a negative number of lines")
+	}
+	for y, s := range []string{"a", "b", "c"} {
+		win.MovePrint(y, 0, s)
+	}
+	first := func() string {
+		return strings.TrimSpace(win.MoveInString(0, 0, 5))
+	}
+	if err := win.ScrollUp(1); err != nil {
+		t.Fatal(err)
+	}
+	if s := first(); s != "b" {
+		t.Errorf("expected \"b\" at the top after scrolling up, got %q", s)
+	}
+	if err := win.ScrollDown(2); err != nil {
+		t.Fatal(err)
+	}
+	if s := first(); s != "" {
+		t.Errorf("expected a blank top line after scrolling down, got %q", s)
+	}
+	if s := strings.TrimSpace(win.MoveInString(2, 0, 5)); s != "b" {
+		t.Errorf("expected \"b\" at the bottom, got %q", s)
+	}
+}
+
+func TestStandout(t *testing.T) {
+	stdscr, end := newTestScreen(t)
+	defer end()
+
+	for _, test := range []struct {
+		on   []bool
+		attr goncurses.Char
+	}{
+		{[]bool{true}, goncurses.A_STANDOUT},
+		{[]bool{false}, goncurses.A_NORMAL},
+		{nil, goncurses.A_STANDOUT},
+	} {
+		if err := stdscr.Standout(test.on...); err != nil {
+			t.Fatal(err)
+		}
+		if attr, _, _ := stdscr.AttrGet(); attr != test.attr {
+			t.Errorf("Standout(%v): expected %#x, got %#x", test.on,
+				test.attr, attr)
+		}
+	}
+}